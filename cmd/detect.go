@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Suprath/orchestrator-cli/internal/detector"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var detectOutput string
+
+// detectReport is what `detect` prints: the chosen profile plus every other match.
+type detectReport struct {
+	Path         string                   `yaml:"path" json:"path"`
+	Detector     string                   `yaml:"detector" json:"detector"`
+	Confidence   float64                  `yaml:"confidence" json:"confidence"`
+	Ambiguous    bool                     `yaml:"ambiguous" json:"ambiguous"`
+	Profile      *detector.ProjectProfile `yaml:"profile" json:"profile"`
+	Evidence     []detector.Evidence      `yaml:"evidence" json:"evidence"`
+	Alternatives []detector.Match         `yaml:"alternatives,omitempty" json:"alternatives,omitempty"`
+}
+
+var detectCmd = &cobra.Command{
+	Use:   "detect [path]",
+	Short: "Prints the detected project profile without generating anything.",
+	Long: `Runs project detection on path (the current directory by default) and
+prints the resulting profile, the evidence behind it and where each inferred
+value came from. In a monorepo, where the services live in subdirectories,
+each service is reported on its own. Exits with a non-zero status when no
+archetype matches, so it can be used in CI to check that a repository is
+classified correctly.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dirPath := "."
+		if len(args) == 1 {
+			dirPath = args[0]
+		}
+		if info, err := os.Stat(dirPath); err != nil || !info.IsDir() {
+			fmt.Printf("❌ %s is not a directory\n", dirPath)
+			os.Exit(1)
+		}
+
+		// A repository of several services gets one report per service.
+		var reports []detectReport
+		matches := detector.DetectAll(dirPath)
+		if services := detector.Discover(dirPath); isMonorepo(services, matches) {
+			for _, service := range services {
+				reports = append(reports, newDetectReport(filepath.Join(dirPath, service.Profile.Path), []detector.Match{service}))
+			}
+		} else {
+			if len(matches) == 0 {
+				fmt.Printf("❌ could not determine project type of %s\n", dirPath)
+				os.Exit(1)
+			}
+			reports = append(reports, newDetectReport(dirPath, matches))
+		}
+
+		// JSON and YAML always hold a list, so scripts read a single project and a monorepo alike.
+		switch detectOutput {
+		case "json":
+			encoded, err := json.MarshalIndent(reports, "", "  ")
+			if err != nil {
+				fmt.Printf("❌ %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println(string(encoded))
+		case "yaml":
+			encoded, err := yaml.Marshal(reports)
+			if err != nil {
+				fmt.Printf("❌ %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Print(string(encoded))
+		case "text":
+			for i, report := range reports {
+				if i > 0 {
+					fmt.Println()
+				}
+				printDetectReport(report)
+			}
+		default:
+			fmt.Printf("❌ invalid --output value %q (expected text, json or yaml)\n", detectOutput)
+			os.Exit(1)
+		}
+	},
+}
+
+// newDetectReport reports the best of matches for dirPath, listing the rest as alternatives.
+func newDetectReport(dirPath string, matches []detector.Match) detectReport {
+	return detectReport{
+		Path:         dirPath,
+		Detector:     matches[0].Detector,
+		Confidence:   matches[0].Confidence,
+		Ambiguous:    detector.Ambiguous(matches),
+		Profile:      matches[0].Profile,
+		Evidence:     matches[0].Evidence,
+		Alternatives: matches[1:],
+	}
+}
+
+func printDetectReport(report detectReport) {
+	fmt.Printf(" Path:             %s\n", report.Path)
+	fmt.Printf(" Archetype:        %s (confidence %.0f%%)\n", report.Profile.Archetype, report.Confidence*100)
+	fmt.Printf(" Language version: %s\n", report.Profile.LanguageVersion)
+	if report.Profile.DatabaseType != "" {
+		fmt.Printf(" Database:         %s%s\n", report.Profile.DatabaseType, otherDatabases(report.Profile.Databases))
+	}
+	if len(report.Profile.BackingServices) > 0 {
+		services := make([]string, len(report.Profile.BackingServices))
+		for i, service := range report.Profile.BackingServices {
+			services[i] = service.Type + " (" + service.Kind + ")"
+		}
+		fmt.Printf(" Services:         %s\n", strings.Join(services, ", "))
+	}
+	if report.Profile.PackageManager != "" {
+		fmt.Printf(" Package manager:  %s%s\n", report.Profile.PackageManager, versionSuffix(report.Profile.PackageManagerVersion))
+	}
+	if len(report.Profile.EnvVars) > 0 {
+		secrets := 0
+		for _, v := range report.Profile.EnvVars {
+			if v.Secret {
+				secrets++
+			}
+		}
+		fmt.Printf(" Environment:      %d variables (%d secret)\n", len(report.Profile.EnvVars), secrets)
+	}
+	if report.Profile.Entrypoint != "" {
+		fmt.Printf(" Entrypoint:       %s\n", report.Profile.Entrypoint)
+	}
+	if report.Profile.Port != 0 {
+		fmt.Printf(" Port:             %d\n", report.Profile.Port)
+	}
+	if report.Profile.HealthPath != "" {
+		fmt.Printf(" Health check:     %s\n", report.Profile.HealthPath)
+	}
+
+	if len(report.Profile.Sources) > 0 {
+		fmt.Println("\n Sources:")
+		fields := make([]string, 0, len(report.Profile.Sources))
+		for field := range report.Profile.Sources {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			fmt.Printf("   %s: %s\n", field, report.Profile.Sources[field])
+		}
+	}
+
+	fmt.Println("\n Evidence:")
+	printEvidence(detector.Match{Evidence: report.Evidence})
+
+	if len(report.Alternatives) > 0 {
+		fmt.Println("\n Other matches:")
+		for _, match := range report.Alternatives {
+			fmt.Printf("   %s (confidence %.0f%%)\n", match.Profile.Archetype, match.Confidence*100)
+			printEvidence(match)
+		}
+		if report.Ambiguous {
+			fmt.Println("\n ⚠️  The top matches are close; 'init' will ask which archetype to use.")
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(detectCmd)
+	detectCmd.Flags().StringVarP(&detectOutput, "output", "o", "text", "output format: text, json or yaml")
+}
+
+// otherDatabases formats the inferred databases after the first for display, e.g. " (also mongodb)".
+func otherDatabases(databases []detector.Database) string {
+	if len(databases) < 2 {
+		return ""
+	}
+	others := make([]string, 0, len(databases)-1)
+	for _, database := range databases[1:] {
+		others = append(others, database.Type)
+	}
+	return " (also " + strings.Join(others, ", ") + ")"
+}
+
+// versionSuffix formats an optional version for display after a tool name.
+func versionSuffix(version string) string {
+	if version == "" {
+		return ""
+	}
+	return " " + version
+}
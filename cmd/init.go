@@ -4,144 +4,107 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 
+	"github.com/Suprath/orchestrator-cli/internal/config"
 	"github.com/Suprath/orchestrator-cli/internal/detector"
 	"github.com/Suprath/orchestrator-cli/internal/generator"
-	"github.com/Suprath/orchestrator-cli/internal/github" // <-- NEW IMPORT
+	"github.com/Suprath/orchestrator-cli/internal/github"
+	"github.com/Suprath/orchestrator-cli/internal/manifest"
 	"github.com/spf13/cobra"
 )
 
+var (
+	initAppName         string
+	initDatabase        string
+	initEnvironment     string
+	initProtectBranches bool
+	initRepo            string
+	initAnswersFile     string
+	initYes             bool
+	initArchetype       string
+	initBackingServices []string
+	initPort            int
+	initHealthPath      string
+	initIngressHost     string
+	initIngressTLS      bool
+)
+
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initializes a new project with a production-ready CI/CD architecture.",
+	Long: `Initializes a new project with a production-ready CI/CD architecture.
+
+Every prompt can also be answered up front with a flag or an answers file,
+so init can run unattended in CI and scripts:
+
+  orchestrator init --app-name my-api --database postgresql --environment cloud --yes
+  orchestrator init --answers answers.yaml --yes`,
 	Run: func(cmd *cobra.Command, args []string) {
-		// --- AUTHENTICATION CHECK ---
-		if err := github.CheckAuthStatus(); err != nil {
+		if err := validateConflictPolicy(); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+		answers, err := loadInitAnswers(cmd)
+		if err != nil {
 			fmt.Printf("❌ %v\n", err)
 			os.Exit(1)
 		}
+		reader := bufio.NewReader(os.Stdin)
 
 		fmt.Println(" Scanning current directory for project type...")
-        currentDir, _ := os.Getwd()
-        profile, err := detector.GetProjectProfile(currentDir)
-        if err != nil {
-            fmt.Printf("❌ %v\n", err)
-            os.Exit(1)
-        }
-
-        fmt.Printf("✅ Detected a %s project.\n", profile.Archetype)
-
-        reader := bufio.NewReader(os.Stdin)
-        fmt.Print(" Enter a short, lowercase name for your application (e.g., 'my-api'): ")
-        appName, _ := reader.ReadString('\n')
-        appName = strings.TrimSpace(appName)
-        if appName == "" {
-            fmt.Println("❌ App name cannot be empty.")
-            os.Exit(1)
-        }
-
-        // Prompt for Database Type
-        fmt.Println("\n Select your database type:")
-        fmt.Println(" 1. MySQL")
-        fmt.Println(" 2. PostgreSQL")
-        fmt.Println(" 3. MongoDB")
-        fmt.Println(" 4. Custom")
-        fmt.Print(" Enter your choice (1-4): ")
-        dbChoiceStr, _ := reader.ReadString('\n')
-        dbChoiceStr = strings.TrimSpace(dbChoiceStr)
-        
-        databaseType := ""
-        switch dbChoiceStr {
-        case "1":
-            databaseType = "mysql"
-        case "2":
-            databaseType = "postgresql"
-        case "3":
-            databaseType = "mongodb"
-        case "4":
-            fmt.Print(" Enter custom database name: ")
-            customDBName, _ := reader.ReadString('\n')
-            databaseType = strings.TrimSpace(customDBName)
-        default:
-            fmt.Println("❌ Invalid database choice. Exiting.")
-            os.Exit(1)
-        }
-
-        // Prompt for Deployment Environment
-        fmt.Println("\n Select your deployment environment:")
-        fmt.Println(" 1. On-Premise")
-        fmt.Println(" 2. Cloud")
-        fmt.Print(" Enter your choice (1-2): ")
-        envChoiceStr, _ := reader.ReadString('\n')
-        envChoiceStr = strings.TrimSpace(envChoiceStr)
-
-        deploymentEnvironment := ""
-        switch envChoiceStr {
-        case "1":
-            deploymentEnvironment = "on_premise"
-        case "2":
-            deploymentEnvironment = "cloud"
-        default:
-            fmt.Println("❌ Invalid deployment environment choice. Exiting.")
-            os.Exit(1)
-        }
-
-        data := generator.TemplateData{
-            AppName: appName,
-            LanguageVersion: profile.LanguageVersion,
-            DatabaseType: databaseType,
-            DeploymentEnvironment: deploymentEnvironment,
-        }
+		currentDir, _ := os.Getwd()
+		var project *config.ProjectConfig
+		matches := detector.DetectAll(currentDir)
+		if services := detector.Discover(currentDir); isMonorepo(services, matches) {
+			project, err = planMonorepo(reader, answers, services)
+		} else {
+			project, err = planProject(reader, answers, matches)
+		}
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
 
 		fmt.Println("\n Generating architectural files...")
 
-		// Expanded list of files to generate
-		filesToGenerate := []struct {
-			TemplatePath string
-			OutputPath   string
-			IsCommon     bool
-		}{
-			{TemplatePath: "common/docker-compose.yml.tmpl", OutputPath: "docker-compose.yml", IsCommon: true},
-			{TemplatePath: "common/terraform/eks_fargate.tf.tmpl", OutputPath: "terraform/main.tf", IsCommon: true},
-			{TemplatePath: "common/kubernetes/deployment.yml.tmpl", OutputPath: "kubernetes/deployment.yml", IsCommon: true},
-			{TemplatePath: "Dockerfile.tmpl", OutputPath: "Dockerfile", IsCommon: false},
-			{TemplatePath: "pipeline.yml.tmpl", OutputPath: ".github/workflows/pipeline.yml", IsCommon: false},
-		}
-
-		for _, file := range filesToGenerate {
-			var templatePath string
-			if file.IsCommon {
-				templatePath = file.TemplatePath
-			} else {
-				templatePath = filepath.Join(string(profile.Archetype), file.TemplatePath)
-			}
+		if err := writeFiles(reader, project.Files, project.Data); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
 
-			outputDir := filepath.Dir(file.OutputPath)
-			if outputDir != "." {
-				_ = os.MkdirAll(outputDir, os.ModePerm)
-			}
+		if dryRun {
+			fmt.Println("\n Dry run complete! No files were written.")
+			return
+		}
 
-			if err := generator.GenerateFile(templatePath, file.OutputPath, data); err != nil {
-				fmt.Printf("❌ Error generating file %s: %v\n", file.OutputPath, err)
-				os.Exit(1)
-			}
-			fmt.Printf("   ✅ Successfully generated %s\n", file.OutputPath)
+		// Persist the answers so 'regenerate' can reproduce this output later.
+		if err := config.SaveProject(cfgFile, project); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
 		}
+		fmt.Printf("   ✅ Saved project configuration to %s\n", cfgFile)
 
 		// --- GITHUB API INTERACTION ---
-		fmt.Print("\n Do you want to apply branch protection rules to this repository on GitHub? (y/n): ")
-		applyProtection, _ := reader.ReadString('\n')
-		if strings.TrimSpace(strings.ToLower(applyProtection)) == "y" {
-			fmt.Print("   Enter the GitHub repository name (e.g., YourUser/YourRepo): ")
-			repoName, _ := reader.ReadString('\n')
-			repoName = strings.TrimSpace(repoName)
-
-			if repoName != "" {
+		if err := askBranchProtection(reader, answers); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+		if *answers.ProtectBranches {
+			if answers.Repo != "" {
+				// Only branch protection talks to GitHub, so only require gh auth here.
+				if err := github.CheckAuthStatus(); err != nil {
+					fmt.Printf("❌ %v\n", err)
+					os.Exit(1)
+				}
 				// We protect 'main' and 'develop' branches by default
-				github.SetBranchProtection(repoName, "main")
-				github.SetBranchProtection(repoName, "develop")
+				for _, branch := range []string{"main", "develop"} {
+					if err := github.SetBranchProtection(answers.Repo, branch); err != nil {
+						fmt.Printf("\n   ⚠️  %v\n", err)
+					}
+				}
 			} else {
 				fmt.Println("   Skipping branch protection, no repository name provided.")
 			}
@@ -151,6 +114,522 @@ var initCmd = &cobra.Command{
 	},
 }
 
+// planProject picks the archetype of a single-service project, asks the
+// remaining questions and returns the configuration to generate from.
+func planProject(reader *bufio.Reader, answers *config.Answers, matches []detector.Match) (*config.ProjectConfig, error) {
+	profile, err := chooseProfile(reader, answers, matches)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("✅ Detected a %s project.\n", profile.Archetype)
+
+	if !detector.ServesHTTP(profile.Archetype) {
+		if answers.IngressHost != nil && *answers.IngressHost != "" {
+			fmt.Printf("⚠️  No Ingress is generated for a %s project: it does not serve HTTP, so put a web server such as nginx in front of it first.\n", profile.Archetype)
+		}
+		none, tls := "", false
+		answers.IngressHost, answers.IngressTLS = &none, &tls
+	}
+	if err := askSharedAnswers(reader, answers, profile.Databases, profile.BackingServices, ""); err != nil {
+		return nil, err
+	}
+	data := templateData(answers.AppName, profile, answers)
+	files := append(generator.FilesFor(string(profile.Archetype)), generator.FilesForBackingServices(data.BackingServices)...)
+	files = append(files, generator.FilesForSettings(data.Settings)...)
+	files = append(files, generator.FilesForIngress(data.IngressHost)...)
+	files, err = withoutProjectEnvExamples(files)
+	if err != nil {
+		return nil, err
+	}
+	return config.NewProjectConfig(*profile, data, files), nil
+}
+
+// askSharedAnswers asks the questions whose answers apply to every service.
+// ingressHint, if any, explains how the Ingress host is used.
+func askSharedAnswers(reader *bufio.Reader, answers *config.Answers, databases []detector.Database, services []detector.BackingService, ingressHint string) error {
+	if err := askAppName(reader, answers); err != nil {
+		return err
+	}
+	if err := askDatabase(reader, answers, databases); err != nil {
+		return err
+	}
+	if err := askBackingServices(reader, answers, services); err != nil {
+		return err
+	}
+	if err := askEnvironment(reader, answers); err != nil {
+		return err
+	}
+	return askIngress(reader, answers, ingressHint)
+}
+
+// templateData combines a detected profile with the user's answers.
+func templateData(appName string, profile *detector.ProjectProfile, answers *config.Answers) generator.TemplateData {
+	port, healthPath := profile.Port, profile.HealthPath
+	if port == 0 {
+		port = detector.DefaultPort(profile.Archetype)
+	}
+	if answers.Port != 0 {
+		port = answers.Port
+	}
+	if answers.HealthPath != "" {
+		healthPath = answers.HealthPath
+	}
+	ingressHost, ingressTLS := *answers.IngressHost, *answers.IngressTLS
+	if !detector.ServesHTTP(profile.Archetype) {
+		// In a monorepo the host is shared, but an Ingress cannot route to this service.
+		ingressHost, ingressTLS = "", false
+	}
+	backing := backingServices(answers.BackingServices)
+	return generator.TemplateData{
+		AppName:               appName,
+		LanguageVersion:       profile.LanguageVersion,
+		DatabaseType:          answers.Database,
+		DeploymentEnvironment: answers.Environment,
+		Framework:             profile.Framework,
+		Entrypoint:            profile.Entrypoint,
+		BuildTool:             profile.BuildTool,
+		BuildToolWrapper:      profile.BuildToolWrapper,
+		PackageManager:        profile.PackageManager,
+		PackageManagerVersion: profile.PackageManagerVersion,
+		NoLockfile:            profile.NoLockfile,
+		AssemblyName:          profile.AssemblyName,
+		RuntimeVersion:        profile.RuntimeVersion,
+		MigrationCommand:      profile.MigrationCommand,
+		Port:                  port,
+		HealthPath:            healthPath,
+		Settings:              settings(profile.EnvVars, backing),
+		IngressHost:           ingressHost,
+		IngressTLS:            ingressTLS,
+		BackingServices:       backing,
+	}
+}
+
+// backingServices converts the chosen service types into template data.
+func backingServices(types []string) []generator.BackingService {
+	var services []generator.BackingService
+	for _, t := range types {
+		services = append(services, generator.BackingService{Type: t})
+	}
+	return services
+}
+
+// settings converts the detected environment variables into template data,
+// leaving out the ones the generated files set themselves.
+func settings(vars []detector.EnvVar, backing []generator.BackingService) []generator.Setting {
+	managed := map[string]bool{"DATABASE_URL": true}
+	for _, service := range backing {
+		managed[service.EnvVar()] = true
+	}
+	var settings []generator.Setting
+	for _, v := range vars {
+		if managed[v.Name] {
+			continue
+		}
+		source := v.Evidence.File
+		if v.Evidence.Line > 0 {
+			source += ":" + strconv.Itoa(v.Evidence.Line)
+		}
+		settings = append(settings, generator.Setting{Name: v.Name, Value: v.Value, Secret: v.Secret, Description: v.Description, Source: source})
+	}
+	return settings
+}
+
+// withoutProjectEnvExamples drops the generated .env.example wherever the
+// project has its own, which documents its settings better than the generated
+// one could. A .env.example generated by an earlier run is kept.
+func withoutProjectEnvExamples(files []generator.FileSpec) ([]generator.FileSpec, error) {
+	m, err := manifest.Load(manifest.Path)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(files, func(file generator.FileSpec) bool {
+		if file.TemplatePath != generator.EnvExampleTemplate {
+			return false
+		}
+		_, tracked := m.Files[file.OutputPath]
+		_, err := os.Stat(file.OutputPath)
+		return err == nil && !tracked
+	}), nil
+}
+
+// loadInitAnswers collects the answers supplied by flags and the --answers file.
+// Flags take precedence over the file.
+func loadInitAnswers(cmd *cobra.Command) (*config.Answers, error) {
+	answers := &config.Answers{
+		Archetype:   strings.TrimSpace(initArchetype),
+		AppName:     strings.TrimSpace(initAppName),
+		Database:    strings.TrimSpace(initDatabase),
+		Environment: strings.TrimSpace(initEnvironment),
+		Repo:        strings.TrimSpace(initRepo),
+		Port:        initPort,
+		HealthPath:  strings.TrimSpace(initHealthPath),
+	}
+	if cmd.Flags().Changed("protect-branches") {
+		protect := initProtectBranches
+		answers.ProtectBranches = &protect
+	}
+	if cmd.Flags().Changed("ingress-host") {
+		host := strings.TrimSpace(initIngressHost)
+		answers.IngressHost = &host
+	}
+	if cmd.Flags().Changed("ingress-tls") {
+		tls := initIngressTLS
+		answers.IngressTLS = &tls
+	}
+	if cmd.Flags().Changed("backing-services") {
+		answers.BackingServices = []string{}
+		for _, service := range initBackingServices {
+			if service = strings.TrimSpace(service); service != "" && service != "none" {
+				answers.BackingServices = append(answers.BackingServices, service)
+			}
+		}
+	}
+
+	if initAnswersFile != "" {
+		fromFile, err := config.LoadAnswers(initAnswersFile)
+		if err != nil {
+			return nil, err
+		}
+		answers.Merge(fromFile)
+	}
+
+	if answers.Environment != "" {
+		if err := config.ValidateEnvironment(answers.Environment); err != nil {
+			return nil, err
+		}
+	}
+	if err := config.ValidateBackingServices(answers.BackingServices); err != nil {
+		return nil, err
+	}
+	if answers.Port != 0 {
+		if err := config.ValidatePort(answers.Port); err != nil {
+			return nil, err
+		}
+	}
+	if answers.HealthPath != "" {
+		if err := config.ValidateHealthPath(answers.HealthPath); err != nil {
+			return nil, err
+		}
+	}
+	if answers.IngressHost != nil && *answers.IngressHost != "" {
+		if err := config.ValidateHost(*answers.IngressHost); err != nil {
+			return nil, err
+		}
+	}
+	return answers, nil
+}
+
+// chooseProfile picks the archetype to generate for. The best match wins,
+// unless the answers name another one or the top scores are too close to
+// call, in which case the user is shown the evidence and asked to choose.
+func chooseProfile(reader *bufio.Reader, answers *config.Answers, matches []detector.Match) (*detector.ProjectProfile, error) {
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("could not determine project type")
+	}
+
+	if answers.Archetype != "" {
+		for _, match := range matches {
+			if string(match.Profile.Archetype) == answers.Archetype {
+				return match.Profile, nil
+			}
+		}
+		return nil, fmt.Errorf("archetype %s does not match this project (detected: %s)", answers.Archetype, matchNames(matches))
+	}
+
+	if !detector.Ambiguous(matches) {
+		printEvidence(matches[0])
+		return matches[0].Profile, nil
+	}
+
+	fmt.Println("\n This project matches more than one archetype:")
+	for i, match := range matches {
+		fmt.Printf(" %d. %s (confidence %.0f%%)\n", i+1, match.Profile.Archetype, match.Confidence*100)
+		printEvidence(match)
+	}
+	if initYes {
+		fmt.Printf(" Using %s; pass --archetype to choose another.\n", matches[0].Profile.Archetype)
+		return matches[0].Profile, nil
+	}
+
+	fmt.Printf(" Enter your choice (1-%d): ", len(matches))
+	choiceStr, _ := reader.ReadString('\n')
+	choice, err := strconv.Atoi(strings.TrimSpace(choiceStr))
+	if err != nil || choice < 1 || choice > len(matches) {
+		return nil, fmt.Errorf("invalid archetype choice %q", strings.TrimSpace(choiceStr))
+	}
+	return matches[choice-1].Profile, nil
+}
+
+func printEvidence(match detector.Match) {
+	for _, e := range match.Evidence {
+		if e.Line > 0 {
+			fmt.Printf("      - %s:%d: %s\n", e.File, e.Line, e.Detail)
+		} else {
+			fmt.Printf("      - %s\n", e.Detail)
+		}
+	}
+}
+
+func matchNames(matches []detector.Match) string {
+	names := make([]string, len(matches))
+	for i, match := range matches {
+		names[i] = string(match.Profile.Archetype)
+	}
+	return strings.Join(names, ", ")
+}
+
+// missingAnswer is the error returned in --yes mode when a required value was not supplied.
+func missingAnswer(flag, key string) error {
+	return fmt.Errorf("missing required value: pass --%s or set '%s' in the answers file", flag, key)
+}
+
+func askAppName(reader *bufio.Reader, answers *config.Answers) error {
+	if answers.AppName != "" {
+		return nil
+	}
+	if initYes {
+		return missingAnswer("app-name", "app_name")
+	}
+
+	fmt.Print(" Enter a short, lowercase name for your application (e.g., 'my-api'): ")
+	appName, _ := reader.ReadString('\n')
+	answers.AppName = strings.TrimSpace(appName)
+	if answers.AppName == "" {
+		return fmt.Errorf("app name cannot be empty")
+	}
+	return nil
+}
+
+// databaseChoices are the numbered database menu entries, in menu order.
+var databaseChoices = []struct{ label, value string }{
+	{"MySQL", detector.DatabaseMySQL},
+	{"PostgreSQL", detector.DatabasePostgreSQL},
+	{"MongoDB", detector.DatabaseMongoDB},
+}
+
+// askDatabase asks for the database type. When the dependencies point to one,
+// it is pre-selected (and used as-is in --yes mode) and the evidence is shown.
+func askDatabase(reader *bufio.Reader, answers *config.Answers, inferred []detector.Database) error {
+	if answers.Database != "" {
+		return nil
+	}
+
+	defaultChoice := ""
+	if len(inferred) > 0 {
+		fmt.Println("\n Databases inferred from your dependencies:")
+		for _, database := range inferred {
+			if e := database.Evidence; e.Line > 0 {
+				fmt.Printf("   %-10s  %s:%d: %s\n", database.Type, e.File, e.Line, e.Detail)
+			} else {
+				fmt.Printf("   %-10s  %s\n", database.Type, e.Detail)
+			}
+		}
+		for i, choice := range databaseChoices {
+			if choice.value == inferred[0].Type {
+				defaultChoice = strconv.Itoa(i + 1)
+			}
+		}
+	}
+	if initYes {
+		if len(inferred) > 0 {
+			answers.Database = inferred[0].Type
+			fmt.Printf(" Using %s; pass --database to choose another.\n", answers.Database)
+			return nil
+		}
+		return missingAnswer("database", "database")
+	}
+
+	// Prompt for Database Type
+	fmt.Println("\n Select your database type:")
+	for i, choice := range databaseChoices {
+		fmt.Printf(" %d. %s\n", i+1, choice.label)
+	}
+	fmt.Printf(" %d. Custom\n", len(databaseChoices)+1)
+	if defaultChoice != "" {
+		fmt.Printf(" Enter your choice (1-%d) [%s]: ", len(databaseChoices)+1, defaultChoice)
+	} else {
+		fmt.Printf(" Enter your choice (1-%d): ", len(databaseChoices)+1)
+	}
+	dbChoiceStr, _ := reader.ReadString('\n')
+	dbChoiceStr = strings.TrimSpace(dbChoiceStr)
+	if dbChoiceStr == "" {
+		dbChoiceStr = defaultChoice
+	}
+
+	choice, err := strconv.Atoi(dbChoiceStr)
+	switch {
+	case err == nil && choice >= 1 && choice <= len(databaseChoices):
+		answers.Database = databaseChoices[choice-1].value
+	case err == nil && choice == len(databaseChoices)+1:
+		fmt.Print(" Enter custom database name: ")
+		customDBName, _ := reader.ReadString('\n')
+		answers.Database = strings.TrimSpace(customDBName)
+	default:
+		return fmt.Errorf("invalid database choice %q", dbChoiceStr)
+	}
+	return nil
+}
+
+// askBackingServices asks which caches, queues and search engines to run next
+// to the app, pre-selecting the ones inferred from the dependencies. In --yes
+// mode the inferred ones are used.
+func askBackingServices(reader *bufio.Reader, answers *config.Answers, inferred []detector.BackingService) error {
+	if answers.BackingServices != nil {
+		return nil
+	}
+
+	choices := generator.BackingServiceTypes()
+	var defaults []string
+	if len(inferred) > 0 {
+		fmt.Println("\n Backing services inferred from your dependencies:")
+	}
+	for _, service := range inferred {
+		if e := service.Evidence; e.Line > 0 {
+			fmt.Printf("   %-13s  %s:%d: %s\n", service.Type, e.File, e.Line, e.Detail)
+		} else {
+			fmt.Printf("   %-13s  %s\n", service.Type, e.Detail)
+		}
+		defaults = append(defaults, strconv.Itoa(slices.Index(choices, service.Type)+1))
+	}
+	if initYes {
+		answers.BackingServices = []string{}
+		for _, service := range inferred {
+			answers.BackingServices = append(answers.BackingServices, service.Type)
+		}
+		return nil
+	}
+
+	fmt.Println("\n Select the backing services to run next to your app:")
+	for i, choice := range choices {
+		fmt.Printf(" %d. %s (%s)\n", i+1, choice, generator.BackingService{Type: choice}.Kind())
+	}
+	defaultChoice := "none"
+	if len(defaults) > 0 {
+		defaultChoice = strings.Join(defaults, ",")
+	}
+	fmt.Printf(" Enter your choices separated by commas, or 'none' [%s]: ", defaultChoice)
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+	if input == "" {
+		input = defaultChoice
+	}
+
+	answers.BackingServices = []string{}
+	if input == "none" {
+		return nil
+	}
+	for _, field := range strings.Split(input, ",") {
+		choice, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || choice < 1 || choice > len(choices) {
+			return fmt.Errorf("invalid backing service choice %q", strings.TrimSpace(field))
+		}
+		if !slices.Contains(answers.BackingServices, choices[choice-1]) {
+			answers.BackingServices = append(answers.BackingServices, choices[choice-1])
+		}
+	}
+	return nil
+}
+
+func askEnvironment(reader *bufio.Reader, answers *config.Answers) error {
+	if answers.Environment != "" {
+		return nil
+	}
+	if initYes {
+		return missingAnswer("environment", "environment")
+	}
+
+	// Prompt for Deployment Environment
+	fmt.Println("\n Select your deployment environment:")
+	fmt.Println(" 1. On-Premise")
+	fmt.Println(" 2. Cloud")
+	fmt.Print(" Enter your choice (1-2): ")
+	envChoiceStr, _ := reader.ReadString('\n')
+	envChoiceStr = strings.TrimSpace(envChoiceStr)
+
+	switch envChoiceStr {
+	case "1":
+		answers.Environment = config.EnvironmentOnPremise
+	case "2":
+		answers.Environment = config.EnvironmentCloud
+	default:
+		return fmt.Errorf("invalid deployment environment choice %q", envChoiceStr)
+	}
+	return nil
+}
+
+// askIngress asks for the host name to expose the app on through an Ingress
+// and, when there is one, whether to serve it over HTTPS. In --yes mode no
+// Ingress is generated unless a host was given.
+func askIngress(reader *bufio.Reader, answers *config.Answers, hint string) error {
+	if answers.IngressHost == nil {
+		host := ""
+		if !initYes {
+			if hint != "" {
+				fmt.Printf("\n An Ingress makes the app reachable from outside the cluster; %s.", hint)
+			}
+			fmt.Print("\n Enter the host name to expose the app on through an Ingress (e.g., api.example.com), or leave blank for none: ")
+			host, _ = reader.ReadString('\n')
+			host = strings.ToLower(strings.TrimSpace(host))
+			if host != "" {
+				if err := config.ValidateHost(host); err != nil {
+					return err
+				}
+			}
+		}
+		answers.IngressHost = &host
+	}
+	if answers.IngressTLS == nil {
+		tls := false
+		if *answers.IngressHost != "" && !initYes {
+			fmt.Print("   Serve it over HTTPS with a certificate from cert-manager? (y/n): ")
+			useTLS, _ := reader.ReadString('\n')
+			tls = strings.TrimSpace(strings.ToLower(useTLS)) == "y"
+		}
+		answers.IngressTLS = &tls
+	}
+	return nil
+}
+
+// askBranchProtection resolves whether to protect branches and, if so, on which repo.
+// In --yes mode protection is skipped unless it was requested explicitly.
+func askBranchProtection(reader *bufio.Reader, answers *config.Answers) error {
+	if answers.ProtectBranches == nil {
+		protect := false
+		if !initYes {
+			fmt.Print("\n Do you want to apply branch protection rules to this repository on GitHub? (y/n): ")
+			applyProtection, _ := reader.ReadString('\n')
+			protect = strings.TrimSpace(strings.ToLower(applyProtection)) == "y"
+		}
+		answers.ProtectBranches = &protect
+	}
+	if !*answers.ProtectBranches || answers.Repo != "" {
+		return nil
+	}
+	if initYes {
+		return missingAnswer("repo", "repo")
+	}
+
+	fmt.Print("   Enter the GitHub repository name (e.g., YourUser/YourRepo): ")
+	repoName, _ := reader.ReadString('\n')
+	answers.Repo = strings.TrimSpace(repoName)
+	return nil
+}
+
 func init() {
 	rootCmd.AddCommand(initCmd)
+
+	initCmd.Flags().StringVar(&initAppName, "app-name", "", "short, lowercase name for your application (e.g. 'my-api')")
+	initCmd.Flags().StringVar(&initDatabase, "database", "", "database type: mysql, postgresql, mongodb or a custom name")
+	initCmd.Flags().StringVar(&initEnvironment, "environment", "", "deployment environment: on_premise or cloud")
+	initCmd.Flags().BoolVar(&initProtectBranches, "protect-branches", false, "apply branch protection rules to 'main' and 'develop' on GitHub")
+	initCmd.Flags().StringVar(&initRepo, "repo", "", "GitHub repository for branch protection (e.g. YourUser/YourRepo)")
+	initCmd.Flags().StringVar(&initAnswersFile, "answers", "", "YAML file with answers for the prompts (flags take precedence)")
+	initCmd.Flags().BoolVarP(&initYes, "yes", "y", false, "never prompt; fail if a required value is missing")
+	initCmd.Flags().StringSliceVar(&initBackingServices, "backing-services", nil, "comma-separated cache, queue and search services to run (e.g. redis,kafka), or 'none'")
+	initCmd.Flags().IntVar(&initPort, "port", 0, "port the app listens on, if not the detected one")
+	initCmd.Flags().StringVar(&initHealthPath, "health-path", "", "HTTP path the Kubernetes probes check (e.g. /healthz), if not the detected one")
+	initCmd.Flags().StringVar(&initIngressHost, "ingress-host", "", "host name to expose the app on through an Ingress (e.g. api.example.com); empty for none")
+	initCmd.Flags().BoolVar(&initIngressTLS, "ingress-tls", false, "serve the Ingress over HTTPS with a certificate from cert-manager")
+	initCmd.Flags().StringVar(&initArchetype, "archetype", "", "archetype to use when the project matches several (e.g. php_laravel)")
+	addWriteFlags(initCmd)
 }
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/Suprath/orchestrator-cli/internal/config"
+	"github.com/Suprath/orchestrator-cli/internal/detector"
+	"github.com/Suprath/orchestrator-cli/internal/generator"
+)
+
+// isMonorepo reports whether discovery found services below the repository
+// root rather than a single project at it. A lone service in a subdirectory
+// only counts when nothing matches at the root, since some detectors (such as
+// ASP.NET Core) already look for their project files a level or two down.
+func isMonorepo(services, rootMatches []detector.Match) bool {
+	if len(services) != 1 {
+		return len(services) > 1
+	}
+	return services[0].Profile.Path != "." && len(rootMatches) == 0
+}
+
+// planMonorepo shows the discovered services, asks the shared questions once
+// and returns a configuration that renders every service's files with its own data.
+func planMonorepo(reader *bufio.Reader, answers *config.Answers, services []detector.Match) (*config.ProjectConfig, error) {
+	if answers.Archetype != "" {
+		return nil, fmt.Errorf("--archetype cannot be used in a monorepo; each service's archetype is detected on its own")
+	}
+	if answers.Port != 0 || answers.HealthPath != "" {
+		return nil, fmt.Errorf("--port and --health-path cannot be used in a monorepo; each service's port and health path are detected on their own")
+	}
+
+	fmt.Printf("✅ Detected a monorepo with %d services:\n", len(services))
+	paths := make([]string, len(services))
+	var databases []detector.Database
+	var backing []detector.BackingService
+	for i, service := range services {
+		paths[i] = service.Profile.Path
+		databases = append(databases, service.Profile.Databases...)
+		for _, candidate := range service.Profile.BackingServices {
+			if !slices.ContainsFunc(backing, func(b detector.BackingService) bool { return b.Type == candidate.Type }) {
+				backing = append(backing, candidate)
+			}
+		}
+		fmt.Printf("   %s: %s (confidence %.0f%%)\n", service.Profile.Path, service.Profile.Archetype, service.Confidence*100)
+		printEvidence(service)
+	}
+
+	// The services share one database and set of backing services, so offer
+	// whichever any of them points to.
+	if err := askSharedAnswers(reader, answers, databases, backing, "each service is exposed on <service>.<host>"); err != nil {
+		return nil, err
+	}
+
+	data := generator.TemplateData{
+		AppName:               answers.AppName,
+		DatabaseType:          answers.Database,
+		DeploymentEnvironment: answers.Environment,
+		BackingServices:       backingServices(answers.BackingServices),
+	}
+	profiles := make([]detector.ProjectProfile, len(services))
+	for i, name := range serviceNames(paths) {
+		profile := services[i].Profile
+		profiles[i] = *profile
+		serviceData := templateData(answers.AppName+"-"+name, profile, answers)
+		if serviceData.IngressHost != "" {
+			serviceData.IngressHost = name + "." + serviceData.IngressHost
+		}
+		data.Services = append(data.Services, generator.Service{
+			Name:      name,
+			Path:      profile.Path,
+			Archetype: string(profile.Archetype),
+			Data:      serviceData,
+		})
+	}
+
+	files := append(generator.MonorepoFilesFor(data.Services), generator.FilesForBackingServices(data.BackingServices)...)
+	files, err := withoutProjectEnvExamples(files)
+	if err != nil {
+		return nil, err
+	}
+	project := config.NewProjectConfig(detector.ProjectProfile{Archetype: detector.ArchetypeMonorepo}, data, files)
+	project.Services = profiles
+	return project, nil
+}
+
+// serviceNames names each service after the last element of its path, e.g.
+// "api" for services/api, falling back to the whole path with dashes
+// ("services-api") when two services would share a name.
+func serviceNames(paths []string) []string {
+	counts := map[string]int{}
+	for _, p := range paths {
+		counts[path.Base(p)]++
+	}
+	names := make([]string, len(paths))
+	for i, p := range paths {
+		names[i] = path.Base(p)
+		if counts[names[i]] > 1 {
+			names[i] = strings.ReplaceAll(p, "/", "-")
+		}
+	}
+	return names
+}
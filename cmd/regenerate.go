@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/Suprath/orchestrator-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var regenerateCmd = &cobra.Command{
+	Use:   "regenerate",
+	Short: "Re-renders the generated files from the saved project configuration.",
+	Long: `Re-renders every file listed in the project configuration (.orchestrator.yaml
+by default) using the profile and answers recorded by 'init'. Nothing is
+detected or prompted for, so the output is the same on every run.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := validateConflictPolicy(); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+		cfg, err := config.LoadProject(cfgFile)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf(" Regenerating %s project '%s' from %s...\n", cfg.Profile.Archetype, cfg.Data.AppName, cfgFile)
+		if err := writeFiles(bufio.NewReader(os.Stdin), cfg.Files, cfg.Data); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+
+		if dryRun {
+			fmt.Println("\n Dry run complete! No files were written.")
+			return
+		}
+
+		fmt.Println("\n Regeneration complete! Review the changes with 'git diff' before committing.")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(regenerateCmd)
+	addWriteFlags(regenerateCmd)
+}
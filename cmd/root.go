@@ -3,10 +3,17 @@ package cmd
 import (
 	"os"
 
+	"github.com/Suprath/orchestrator-cli/internal/config"
+	"github.com/Suprath/orchestrator-cli/internal/templates"
 	"github.com/spf13/cobra"
 )
 
-
+var (
+	// cfgFile is the project config written by `init` and read back by `regenerate`.
+	cfgFile string
+	// templateDir is an extra template layer: a directory or a tarball pack.
+	templateDir string
+)
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
@@ -21,6 +28,19 @@ to quickly create a Cobra application.`,
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	// Run: func(cmd *cobra.Command, args []string) { },
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Resolve templates through the project and user override layers before any command renders.
+		currentDir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		layered, err := templates.NewLayeredFS(currentDir, templateDir)
+		if err != nil {
+			return err
+		}
+		templates.Source = layered
+		return nil
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -37,11 +57,10 @@ func init() {
 	// Cobra supports persistent flags, which, if defined here,
 	// will be global for your application.
 
-	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.orchestrator-cli.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", config.ProjectFileName, "project config file")
+	rootCmd.PersistentFlags().StringVar(&templateDir, "template-dir", "", "directory or .tar/.tar.gz template pack that overrides the built-in templates")
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 }
-
-
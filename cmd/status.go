@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Suprath/orchestrator-cli/internal/config"
+	"github.com/Suprath/orchestrator-cli/internal/manifest"
+	"github.com/spf13/cobra"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Shows which generated files were edited, removed or are out of date.",
+	Long: `Compares every generated file with the checksums recorded in
+` + manifest.Path + ` when it was last written, and reports it as:
+
+  pristine   exactly as generated, and the template is unchanged
+  stale      untouched, but the template or answers changed; safe to regenerate
+  modified   edited since generation; regenerating would discard those edits
+  missing    generated earlier but deleted since
+  untracked  listed in the project config but never recorded in the manifest`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := config.LoadProject(cfgFile)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+		m, err := manifest.Load(manifest.Path)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+
+		for _, file := range cfg.Files {
+			status, err := m.Check(file, cfg.Data.For(file))
+			if err != nil {
+				fmt.Printf("❌ %v\n", err)
+				os.Exit(1)
+			}
+
+			note := ""
+			if status.State == manifest.StateModified && status.TemplateChanged {
+				note = "  (template also changed; use 'orchestrator upgrade' to merge)"
+			}
+			fmt.Printf(" %-10s %s%s\n", status.State, status.Path, note)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+}
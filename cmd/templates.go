@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Suprath/orchestrator-cli/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "Inspects the templates used to generate files.",
+	Long: `Templates are looked up in these layers, first match wins:
+
+  project       .orchestrator/templates/ in the current directory
+  user          $XDG_CONFIG_HOME/orchestrator/templates/
+  template-dir  the --template-dir directory or .tar/.tar.gz pack
+  embedded      the defaults built into the CLI
+
+Each layer mirrors the embedded layout, so a single file such as
+python_fastapi/Dockerfile.tmpl or a whole archetype directory can be
+overridden on its own.`,
+}
+
+var templatesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists every template and the layer it resolves from.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		layered, ok := templates.Source.(*templates.LayeredFS)
+		if !ok {
+			fmt.Println("❌ template layers have not been initialised")
+			os.Exit(1)
+		}
+
+		resolved, err := layered.List()
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+
+		for _, r := range resolved {
+			location := ""
+			if r.Layer.Location != "" {
+				location = "  (" + r.Layer.Location + ")"
+			}
+			fmt.Printf(" %-45s %s%s\n", r.Name, r.Layer.Name, location)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(templatesCmd)
+	templatesCmd.AddCommand(templatesListCmd)
+}
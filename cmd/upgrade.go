@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/Suprath/orchestrator-cli/internal/config"
+	"github.com/Suprath/orchestrator-cli/internal/diff"
+	"github.com/Suprath/orchestrator-cli/internal/generator"
+	"github.com/Suprath/orchestrator-cli/internal/manifest"
+	"github.com/spf13/cobra"
+)
+
+var upgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Merges the latest templates into previously generated files, keeping local edits.",
+	Long: `Re-renders every generated file with the current templates and merges the
+result into the file on disk with a three-way merge:
+
+  base    the output the CLI originally generated (kept in ` + manifest.BaseDir + `)
+  ours    the file as it is now, including your edits
+  theirs  the output of the current templates
+
+Changes made on only one side are applied automatically. When both sides
+changed the same lines, the file gets git-style conflict markers and the
+command exits with a non-zero status once every file has been processed.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := config.LoadProject(cfgFile)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+		m, err := manifest.Load(manifest.Path)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf(" Upgrading %s project '%s'...\n", cfg.Profile.Archetype, cfg.Data.AppName)
+		conflicted := 0
+		for _, file := range cfg.Files {
+			conflicts, err := upgradeFile(m, file, cfg.Data.For(file))
+			if err != nil {
+				fmt.Printf("❌ %v\n", err)
+				os.Exit(1)
+			}
+			if conflicts > 0 {
+				conflicted++
+			}
+		}
+
+		if dryRun {
+			fmt.Println("\n Dry run complete! No files were written.")
+			return
+		}
+		if err := m.Save(manifest.Path); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+
+		if conflicted > 0 {
+			fmt.Printf("\n❌ %d file(s) have merge conflicts. Resolve the conflict markers, then run 'orchestrator upgrade' again.\n", conflicted)
+			os.Exit(1)
+		}
+		fmt.Println("\n Upgrade complete! Review the changes with 'git diff' before committing.")
+	},
+}
+
+// upgradeFile merges the current template output into one file and returns the number of conflicts.
+func upgradeFile(m *manifest.Manifest, file generator.FileSpec, data generator.TemplateData) (int, error) {
+	theirs, err := generator.Render(file.TemplatePath, data)
+	if err != nil {
+		return 0, fmt.Errorf("error generating file %s: %w", file.OutputPath, err)
+	}
+
+	ours, err := os.ReadFile(file.OutputPath)
+	if os.IsNotExist(err) {
+		fmt.Printf("   ⏭️  Skipped %s (missing; run 'orchestrator regenerate' to restore it)\n", file.OutputPath)
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", file.OutputPath, err)
+	}
+
+	base, ok, err := manifest.ReadBase(file.OutputPath)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		// Files generated before bases were kept can still be upgraded if they were never edited.
+		if entry, tracked := m.Files[file.OutputPath]; tracked && entry.OutputHash == manifest.Hash(ours) {
+			base = ours
+		} else if !bytes.Equal(ours, theirs) {
+			fmt.Printf("   ⚠️  Skipped %s (no generated base recorded; use 'orchestrator regenerate --on-conflict=prompt')\n", file.OutputPath)
+			return 0, nil
+		} else {
+			base = ours
+		}
+	}
+
+	merged, conflicts := diff.Merge(string(base), string(ours), string(theirs), file.OutputPath+" (local)", file.OutputPath+" (template)")
+	if merged == string(ours) {
+		// The template is unchanged since the last upgrade, but that one may have left conflicts behind.
+		if unresolved := diff.ConflictMarkers(merged); unresolved > 0 {
+			fmt.Printf("   ❌ %s still has %d unresolved conflict(s)\n", file.OutputPath, unresolved)
+			return unresolved, nil
+		}
+		if !dryRun {
+			if err := recordFile(m, file, data, theirs); err != nil {
+				return 0, err
+			}
+		}
+		fmt.Printf("   = %s is up to date\n", file.OutputPath)
+		return 0, nil
+	}
+
+	if dryRun {
+		fmt.Print(diff.Unified("a/"+file.OutputPath, "b/"+file.OutputPath, string(ours), merged))
+		return conflicts, nil
+	}
+
+	if err := os.WriteFile(file.OutputPath, []byte(merged), 0644); err != nil {
+		return 0, fmt.Errorf("failed to write %s: %w", file.OutputPath, err)
+	}
+	// The template side of the merge is settled either way, so it becomes the
+	// base; once the user resolves the markers the next upgrade merges cleanly.
+	if err := recordFile(m, file, data, theirs); err != nil {
+		return 0, err
+	}
+	if conflicts > 0 {
+		fmt.Printf("   ❌ %s has %d conflict(s)\n", file.OutputPath, conflicts)
+		return conflicts, nil
+	}
+	fmt.Printf("   ✅ Merged template changes into %s\n", file.OutputPath)
+	return 0, nil
+}
+
+func init() {
+	rootCmd.AddCommand(upgradeCmd)
+	upgradeCmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the diff each merge would apply instead of writing")
+}
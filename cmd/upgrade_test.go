@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Suprath/orchestrator-cli/internal/generator"
+	"github.com/Suprath/orchestrator-cli/internal/manifest"
+)
+
+func TestUpgradeFile_ResolvedConflictMergesCleanly(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "orchestrator-test-upgrade-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// The base lives relative to the project root, so run from inside it.
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(wd)
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	data := generator.TemplateData{AppName: "demo", LanguageVersion: "3.11", DatabaseType: "postgresql", DeploymentEnvironment: "cloud", Port: 8000}
+	file := generator.FileSpec{TemplatePath: "python_fastapi/Dockerfile.tmpl", OutputPath: "Dockerfile"}
+	theirs, err := generator.Render(file.TemplatePath, data)
+	if err != nil {
+		t.Fatalf("Failed to render template: %v", err)
+	}
+
+	// The template and the user both changed the first line since the base was generated.
+	rest := string(theirs)[strings.Index(string(theirs), "\n"):]
+	if err := manifest.StoreBase(file.OutputPath, []byte("# generated by an older CLI"+rest)); err != nil {
+		t.Fatalf("Failed to store base: %v", err)
+	}
+	ours := "# edited by the user" + rest
+	os.WriteFile(file.OutputPath, []byte(ours), 0644)
+
+	m, err := manifest.Load(manifest.Path)
+	if err != nil {
+		t.Fatalf("Did not expect an error, but got: %v", err)
+	}
+
+	conflicts, err := upgradeFile(m, file, data)
+	if err != nil {
+		t.Fatalf("Did not expect an error, but got: %v", err)
+	}
+	if conflicts != 1 {
+		t.Fatalf("Expected 1 conflict, but got %d", conflicts)
+	}
+
+	// Upgrading again before resolving must still report the conflict.
+	conflicts, err = upgradeFile(m, file, data)
+	if err != nil {
+		t.Fatalf("Did not expect an error, but got: %v", err)
+	}
+	if conflicts != 1 {
+		t.Errorf("Expected the unresolved conflict to be reported again, but got %d conflicts", conflicts)
+	}
+
+	// Resolve the conflict in favour of the local edit, then upgrade again.
+	os.WriteFile(file.OutputPath, []byte(ours), 0644)
+	conflicts, err = upgradeFile(m, file, data)
+	if err != nil {
+		t.Fatalf("Did not expect an error, but got: %v", err)
+	}
+	if conflicts != 0 {
+		t.Errorf("Expected no conflicts after resolving, but got %d", conflicts)
+	}
+	content, _ := os.ReadFile(file.OutputPath)
+	if string(content) != ours {
+		t.Errorf("Expected the resolved file to be kept, but got:\n%s", content)
+	}
+}
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Suprath/orchestrator-cli/internal/diff"
+	"github.com/Suprath/orchestrator-cli/internal/generator"
+	"github.com/Suprath/orchestrator-cli/internal/manifest"
+	"github.com/spf13/cobra"
+)
+
+// Conflict policies for generated files that already exist with different content.
+const (
+	conflictSkip      = "skip"
+	conflictOverwrite = "overwrite"
+	conflictBackup    = "backup"
+	conflictPrompt    = "prompt"
+)
+
+var (
+	dryRun     bool
+	onConflict string
+)
+
+// addWriteFlags registers the flags shared by every command that writes generated files.
+func addWriteFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "render every file in memory and print a unified diff instead of writing")
+	cmd.Flags().StringVar(&onConflict, "on-conflict", conflictPrompt, "what to do when a file already exists with different content: skip, overwrite, backup or prompt")
+}
+
+func validateConflictPolicy() error {
+	switch onConflict {
+	case conflictSkip, conflictOverwrite, conflictBackup, conflictPrompt:
+		return nil
+	}
+	return fmt.Errorf("invalid --on-conflict value %q (expected skip, overwrite, backup or prompt)", onConflict)
+}
+
+// writeFiles renders every file in order. Files that are new or unchanged are
+// written directly; existing files with different content go through the
+// --on-conflict policy. Everything written is recorded in the manifest.
+// With --dry-run nothing is written and a diff is printed instead.
+func writeFiles(reader *bufio.Reader, files []generator.FileSpec, data generator.TemplateData) error {
+	m, err := manifest.Load(manifest.Path)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		data := data.For(file)
+		rendered, err := generator.Render(file.TemplatePath, data)
+		if err != nil {
+			return fmt.Errorf("error generating file %s: %w", file.OutputPath, err)
+		}
+
+		existing, err := os.ReadFile(file.OutputPath)
+		exists := err == nil
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read %s: %w", file.OutputPath, err)
+		}
+
+		if exists && bytes.Equal(existing, rendered) {
+			if !dryRun {
+				if err := recordFile(m, file, data, rendered); err != nil {
+					return err
+				}
+			}
+			fmt.Printf("   = %s is up to date\n", file.OutputPath)
+			continue
+		}
+
+		if dryRun {
+			fromName := "a/" + file.OutputPath
+			if !exists {
+				fromName = "/dev/null"
+			}
+			fmt.Print(diff.Unified(fromName, "b/"+file.OutputPath, string(existing), string(rendered)))
+			continue
+		}
+
+		if exists {
+			proceed, err := resolveConflict(reader, file.OutputPath, existing, rendered)
+			if err != nil {
+				return err
+			}
+			if !proceed {
+				fmt.Printf("   ⏭️  Skipped %s (already exists)\n", file.OutputPath)
+				continue
+			}
+		}
+
+		outputDir := filepath.Dir(file.OutputPath)
+		if outputDir != "." {
+			_ = os.MkdirAll(outputDir, os.ModePerm)
+		}
+		if err := os.WriteFile(file.OutputPath, rendered, 0644); err != nil {
+			return fmt.Errorf("error generating file %s: %w", file.OutputPath, err)
+		}
+		if err := recordFile(m, file, data, rendered); err != nil {
+			return err
+		}
+		fmt.Printf("   ✅ Successfully generated %s\n", file.OutputPath)
+	}
+
+	if dryRun {
+		return nil
+	}
+	return m.Save(manifest.Path)
+}
+
+// recordFile remembers rendered as the generated version of file, both in the
+// manifest and as the base for future three-way merges.
+func recordFile(m *manifest.Manifest, file generator.FileSpec, data generator.TemplateData, rendered []byte) error {
+	if err := m.Record(file, data, rendered); err != nil {
+		return err
+	}
+	return manifest.StoreBase(file.OutputPath, rendered)
+}
+
+// resolveConflict applies the --on-conflict policy to an existing file and
+// reports whether the new content should be written over it.
+func resolveConflict(reader *bufio.Reader, path string, existing, rendered []byte) (bool, error) {
+	policy := onConflict
+	if policy == conflictPrompt {
+		if initYes {
+			// Nobody is there to answer; never clobber a file unattended.
+			return false, nil
+		}
+		policy = promptConflict(reader, path, existing, rendered)
+	}
+
+	switch policy {
+	case conflictOverwrite:
+		return true, nil
+	case conflictBackup:
+		backupPath, err := backupFile(path)
+		if err != nil {
+			return false, err
+		}
+		fmt.Printf("   💾 Backed up %s to %s\n", path, backupPath)
+		return true, nil
+	}
+	return false, nil
+}
+
+// promptConflict asks the user what to do with an existing file, showing the diff on request.
+func promptConflict(reader *bufio.Reader, path string, existing, rendered []byte) string {
+	for {
+		fmt.Printf("\n %s already exists and differs from the generated version.\n", path)
+		fmt.Print(" [o]verwrite, [s]kip, [b]ackup and overwrite, show [d]iff? (s): ")
+		answer, err := reader.ReadString('\n')
+		answer = strings.TrimSpace(strings.ToLower(answer))
+
+		switch answer {
+		case "o", "overwrite":
+			return conflictOverwrite
+		case "b", "backup":
+			return conflictBackup
+		case "d", "diff":
+			fmt.Print(diff.Unified("a/"+path, "b/"+path, string(existing), string(rendered)))
+		case "", "s", "skip":
+			return conflictSkip
+		}
+		if err != nil {
+			// stdin is closed, so asking again would loop forever.
+			return conflictSkip
+		}
+	}
+}
+
+// backupFile renames path to the first free "<path>.bak", "<path>.bak.1", ... name.
+func backupFile(path string) (string, error) {
+	backupPath := path + ".bak"
+	for i := 1; ; i++ {
+		if _, err := os.Stat(backupPath); os.IsNotExist(err) {
+			break
+		}
+		backupPath = fmt.Sprintf("%s.bak.%d", path, i)
+	}
+	if err := os.Rename(path, backupPath); err != nil {
+		return "", fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	return backupPath, nil
+}
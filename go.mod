@@ -5,6 +5,7 @@ go 1.24.5
 require (
 	github.com/Masterminds/semver v1.5.0
 	github.com/spf13/cobra v1.10.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Suprath/orchestrator-cli/internal/generator"
+	"gopkg.in/yaml.v3"
+)
+
+// Supported values for the deployment environment answer.
+const (
+	EnvironmentOnPremise = "on_premise"
+	EnvironmentCloud     = "cloud"
+)
+
+// Answers holds every value `init` would otherwise prompt for.
+// Empty fields are asked for interactively, or rejected in --yes mode.
+type Answers struct {
+	Archetype       string `yaml:"archetype"`
+	AppName         string `yaml:"app_name"`
+	Database        string `yaml:"database"`
+	Environment     string `yaml:"environment"`
+	ProtectBranches *bool  `yaml:"protect_branches"`
+	Repo            string `yaml:"repo"`
+	// BackingServices are the cache, queue and search services to run next to
+	// the app. nil means not answered yet; an empty list means none.
+	BackingServices []string `yaml:"backing_services"`
+	// Port and HealthPath override the detected port and health check path.
+	Port       int    `yaml:"port"`
+	HealthPath string `yaml:"health_path"`
+	// IngressHost is the host name to expose the app on; nil means not
+	// answered yet and an empty string means no Ingress.
+	IngressHost *string `yaml:"ingress_host"`
+	IngressTLS  *bool   `yaml:"ingress_tls"`
+}
+
+// LoadAnswers reads an answers file such as answers.yaml.
+func LoadAnswers(path string) (*Answers, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read answers file: %w", err)
+	}
+
+	var answers Answers
+	if err := yaml.Unmarshal(data, &answers); err != nil {
+		return nil, fmt.Errorf("failed to parse answers file %s: %w", path, err)
+	}
+	return &answers, nil
+}
+
+// Merge fills any empty field of a with the value from other.
+// Values already set on a win, so flags can be layered over a file.
+func (a *Answers) Merge(other *Answers) {
+	if other == nil {
+		return
+	}
+	if a.Archetype == "" {
+		a.Archetype = other.Archetype
+	}
+	if a.AppName == "" {
+		a.AppName = other.AppName
+	}
+	if a.Database == "" {
+		a.Database = other.Database
+	}
+	if a.Environment == "" {
+		a.Environment = other.Environment
+	}
+	if a.ProtectBranches == nil {
+		a.ProtectBranches = other.ProtectBranches
+	}
+	if a.Repo == "" {
+		a.Repo = other.Repo
+	}
+	if a.BackingServices == nil {
+		a.BackingServices = other.BackingServices
+	}
+	if a.Port == 0 {
+		a.Port = other.Port
+	}
+	if a.HealthPath == "" {
+		a.HealthPath = other.HealthPath
+	}
+	if a.IngressHost == nil {
+		a.IngressHost = other.IngressHost
+	}
+	if a.IngressTLS == nil {
+		a.IngressTLS = other.IngressTLS
+	}
+}
+
+// ValidateEnvironment checks that env is one of the supported deployment environments.
+func ValidateEnvironment(env string) error {
+	switch env {
+	case EnvironmentOnPremise, EnvironmentCloud:
+		return nil
+	}
+	return fmt.Errorf("invalid deployment environment %q (expected %q or %q)", env, EnvironmentOnPremise, EnvironmentCloud)
+}
+
+// ValidatePort checks that port is a valid TCP port number.
+func ValidatePort(port int) error {
+	if port < 1 || port > 65535 {
+		return fmt.Errorf("invalid port %d (expected 1-65535)", port)
+	}
+	return nil
+}
+
+// ValidateHealthPath checks that path is an absolute URL path such as /healthz.
+func ValidateHealthPath(path string) error {
+	if !strings.HasPrefix(path, "/") || strings.ContainsAny(path, " \t\"") {
+		return fmt.Errorf("invalid health path %q (expected an absolute path such as /healthz)", path)
+	}
+	return nil
+}
+
+// hostPattern matches a DNS name such as api.example.com.
+var hostPattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)*[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// ValidateHost checks that host is a lowercase DNS name an Ingress can route.
+func ValidateHost(host string) error {
+	if len(host) > 253 || !hostPattern.MatchString(host) {
+		return fmt.Errorf("invalid ingress host %q (expected a lowercase DNS name such as api.example.com)", host)
+	}
+	return nil
+}
+
+// ValidateBackingServices checks that every service is one the templates can render.
+func ValidateBackingServices(services []string) error {
+	supported := generator.BackingServiceTypes()
+	for _, service := range services {
+		if !slices.Contains(supported, service) {
+			return fmt.Errorf("invalid backing service %q (expected one of %s)", service, strings.Join(supported, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadAnswers_FlagsTakePrecedence(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "orchestrator-test-answers-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	answersPath := filepath.Join(tempDir, "answers.yaml")
+	os.WriteFile(answersPath, []byte(`app_name: from-file
+database: mysql
+environment: cloud
+protect_branches: true
+repo: acme/api
+`), 0644)
+
+	fromFile, err := LoadAnswers(answersPath)
+	if err != nil {
+		t.Fatalf("Did not expect an error, but got: %v", err)
+	}
+
+	// Simulate --app-name being passed on the command line.
+	answers := &Answers{AppName: "from-flag"}
+	answers.Merge(fromFile)
+
+	if answers.AppName != "from-flag" {
+		t.Errorf("Expected app name %s, but got %s", "from-flag", answers.AppName)
+	}
+	if answers.Database != "mysql" {
+		t.Errorf("Expected database %s, but got %s", "mysql", answers.Database)
+	}
+	if answers.ProtectBranches == nil || !*answers.ProtectBranches {
+		t.Errorf("Expected protect_branches to be true")
+	}
+	if answers.Repo != "acme/api" {
+		t.Errorf("Expected repo %s, but got %s", "acme/api", answers.Repo)
+	}
+}
+
+func TestValidateEnvironment(t *testing.T) {
+	for _, env := range []string{EnvironmentOnPremise, EnvironmentCloud} {
+		if err := ValidateEnvironment(env); err != nil {
+			t.Errorf("Did not expect an error for %s, but got: %v", env, err)
+		}
+	}
+	if err := ValidateEnvironment("mars"); err == nil {
+		t.Errorf("Expected an error for an unknown environment, but got none")
+	}
+}
+
+func TestValidateBackingServices(t *testing.T) {
+	if err := ValidateBackingServices([]string{"redis", "kafka", "elasticsearch"}); err != nil {
+		t.Errorf("Did not expect an error, but got: %v", err)
+	}
+	if err := ValidateBackingServices([]string{"redis", "mongo"}); err == nil {
+		t.Errorf("Expected an error for an unknown backing service, but got none")
+	}
+}
+
+func TestMerge_EmptyBackingServicesMeansNone(t *testing.T) {
+	answers := &Answers{BackingServices: []string{}}
+	answers.Merge(&Answers{BackingServices: []string{"redis"}})
+	if len(answers.BackingServices) != 0 {
+		t.Errorf("Expected an explicit empty list to be kept, but got %v", answers.BackingServices)
+	}
+
+	answers = &Answers{}
+	answers.Merge(&Answers{BackingServices: []string{"redis"}})
+	if len(answers.BackingServices) != 1 {
+		t.Errorf("Expected backing services from the file, but got %v", answers.BackingServices)
+	}
+}
+
+func TestValidateHost(t *testing.T) {
+	for _, host := range []string{"example.com", "api.example.com", "my-app.internal"} {
+		if err := ValidateHost(host); err != nil {
+			t.Errorf("Did not expect an error for %q, but got: %v", host, err)
+		}
+	}
+	for _, host := range []string{"Example.com", "https://example.com", "-api.example.com", "api..example.com", "api.example.com/"} {
+		if err := ValidateHost(host); err == nil {
+			t.Errorf("Expected an error for %q, but got none", host)
+		}
+	}
+}
+
+func TestMerge_EmptyIngressHostMeansNone(t *testing.T) {
+	none := ""
+	answers := &Answers{IngressHost: &none}
+	host := "api.example.com"
+	answers.Merge(&Answers{IngressHost: &host})
+	if *answers.IngressHost != "" {
+		t.Errorf("Expected an explicit empty host to be kept, but got %q", *answers.IngressHost)
+	}
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Suprath/orchestrator-cli/internal/detector"
+	"github.com/Suprath/orchestrator-cli/internal/generator"
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectFileName is the default name of the project config written by `init`.
+const ProjectFileName = ".orchestrator.yaml"
+
+// ProjectConfigVersion is the schema version written to new project configs.
+// Bump it whenever a field changes meaning, and teach LoadProject to migrate older files.
+// Version 2 lists the Service, ServiceAccount, ConfigMap and Secret the Deployment refers to.
+const ProjectConfigVersion = 2
+
+// ProjectConfig is everything needed to reproduce a previous `init` run.
+type ProjectConfig struct {
+	Version int                     `yaml:"version"`
+	Profile detector.ProjectProfile `yaml:"profile"`
+	Data    generator.TemplateData  `yaml:"data"`
+	Files   []generator.FileSpec    `yaml:"files"`
+	// Services holds the profile of each service when the project is a monorepo.
+	Services []detector.ProjectProfile `yaml:"services,omitempty"`
+}
+
+// NewProjectConfig builds a config at the current schema version.
+func NewProjectConfig(profile detector.ProjectProfile, data generator.TemplateData, files []generator.FileSpec) *ProjectConfig {
+	return &ProjectConfig{
+		Version: ProjectConfigVersion,
+		Profile: profile,
+		Data:    data,
+		Files:   files,
+	}
+}
+
+// LoadProject reads and validates a project config.
+func LoadProject(path string) (*ProjectConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%s not found, run 'orchestrator init' first", path)
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var cfg ProjectConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	if cfg.Version == 0 {
+		return nil, fmt.Errorf("%s has no 'version' field", path)
+	}
+	if cfg.Version > ProjectConfigVersion {
+		return nil, fmt.Errorf("%s was written by a newer orchestrator (config version %d, this build supports %d); please upgrade the CLI", path, cfg.Version, ProjectConfigVersion)
+	}
+	if len(cfg.Files) == 0 {
+		return nil, fmt.Errorf("%s does not list any generated files", path)
+	}
+
+	// Configs written before ports were detected rendered each archetype's default port.
+	if cfg.Data.Port == 0 && len(cfg.Data.Services) == 0 {
+		cfg.Data.Port = detector.DefaultPort(cfg.Profile.Archetype)
+	}
+	for i := range cfg.Data.Services {
+		if service := &cfg.Data.Services[i]; service.Data.Port == 0 {
+			service.Data.Port = detector.DefaultPort(detector.Archetype(service.Archetype))
+		}
+	}
+	// Version 1 configs predate the manifests the Deployment now refers to.
+	if cfg.Version < 2 {
+		cfg.Files = addKubernetesFiles(cfg.Files, &cfg)
+	}
+	cfg.Version = ProjectConfigVersion
+	return &cfg, nil
+}
+
+// addKubernetesFiles appends the Kubernetes manifests the current templates
+// generate for cfg that files does not list yet.
+func addKubernetesFiles(files []generator.FileSpec, cfg *ProjectConfig) []generator.FileSpec {
+	expected := generator.FilesFor(string(cfg.Profile.Archetype))
+	if len(cfg.Data.Services) > 0 {
+		expected = generator.MonorepoFilesFor(cfg.Data.Services)
+	}
+	listed := map[string]bool{}
+	for _, file := range files {
+		listed[file.OutputPath] = true
+	}
+	for _, file := range expected {
+		if strings.HasPrefix(file.TemplatePath, "common/kubernetes/") && !listed[file.OutputPath] {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// SaveProject writes cfg to path.
+func SaveProject(path string, cfg *ProjectConfig) error {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to encode project config: %w", err)
+	}
+
+	header := []byte("# Generated by orchestrator init. Run 'orchestrator regenerate' to re-render the files below.\n")
+	if err := os.WriteFile(path, append(header, data...), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Suprath/orchestrator-cli/internal/detector"
+	"github.com/Suprath/orchestrator-cli/internal/generator"
+)
+
+func TestSaveProject_RoundTrip(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "orchestrator-test-config-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	profile := detector.ProjectProfile{Archetype: detector.ArchetypePythonFastAPI, LanguageVersion: "3.9"}
+	data := generator.TemplateData{AppName: "demo", LanguageVersion: "3.9", DatabaseType: "postgresql", DeploymentEnvironment: EnvironmentCloud, Port: 8000}
+	want := NewProjectConfig(profile, data, generator.FilesFor(string(profile.Archetype)))
+
+	configPath := filepath.Join(tempDir, ProjectFileName)
+	if err := SaveProject(configPath, want); err != nil {
+		t.Fatalf("Did not expect an error saving, but got: %v", err)
+	}
+	got, err := LoadProject(configPath)
+	if err != nil {
+		t.Fatalf("Did not expect an error loading, but got: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Round trip mismatch:\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+func TestLoadProject_RejectsNewerVersion(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "orchestrator-test-config-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	configPath := filepath.Join(tempDir, ProjectFileName)
+	os.WriteFile(configPath, []byte("version: 99\nfiles:\n  - template: a\n    output: b\n"), 0644)
+
+	if _, err := LoadProject(configPath); err == nil {
+		t.Errorf("Expected an error for a newer config version, but got none")
+	}
+}
+
+func TestLoadProject_DefaultsPortOfOlderConfigs(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "orchestrator-test-config-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	configPath := filepath.Join(tempDir, ProjectFileName)
+	os.WriteFile(configPath, []byte("version: 1\nprofile:\n  archetype: java_spring_boot\ndata:\n  app_name: demo\nfiles:\n  - template: a\n    output: b\n"), 0644)
+
+	cfg, err := LoadProject(configPath)
+	if err != nil {
+		t.Fatalf("Did not expect an error loading, but got: %v", err)
+	}
+	if cfg.Data.Port != 8080 {
+		t.Errorf("Expected port 8080 for a Spring Boot config without one, but got %d", cfg.Data.Port)
+	}
+}
+
+func TestLoadProject_AddsKubernetesFilesToVersion1Configs(t *testing.T) {
+	testCases := []struct {
+		name          string
+		config        string
+		expectedFiles []string
+	}{
+		{
+			name:          "Single service",
+			config:        "version: 1\nprofile:\n  archetype: python_fastapi\ndata:\n  app_name: demo\nfiles:\n  - template: common/kubernetes/deployment.yml.tmpl\n    output: kubernetes/deployment.yml\n",
+			expectedFiles: []string{"kubernetes/deployment.yml", "kubernetes/service.yml", "kubernetes/serviceaccount.yml", "kubernetes/configmap.yml", "kubernetes/secret.yml"},
+		},
+		{
+			name:          "Monorepo",
+			config:        "version: 1\nprofile:\n  archetype: monorepo\ndata:\n  app_name: demo\n  services:\n    - name: api\n      path: services/api\n      archetype: python_fastapi\n      data:\n        app_name: demo-api\nfiles:\n  - template: common/kubernetes/deployment.yml.tmpl\n    output: kubernetes/api/deployment.yml\n    service: api\n",
+			expectedFiles: []string{"kubernetes/api/deployment.yml", "kubernetes/api/service.yml", "kubernetes/api/serviceaccount.yml", "kubernetes/api/configmap.yml", "kubernetes/api/secret.yml"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tempDir, err := os.MkdirTemp("", "orchestrator-test-config-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			configPath := filepath.Join(tempDir, ProjectFileName)
+			os.WriteFile(configPath, []byte(tc.config), 0644)
+
+			cfg, err := LoadProject(configPath)
+			if err != nil {
+				t.Fatalf("Did not expect an error loading, but got: %v", err)
+			}
+			if cfg.Version != ProjectConfigVersion {
+				t.Errorf("Expected version %d after migration, but got %d", ProjectConfigVersion, cfg.Version)
+			}
+			var files []string
+			for _, file := range cfg.Files {
+				files = append(files, file.OutputPath)
+			}
+			if !reflect.DeepEqual(files, tc.expectedFiles) {
+				t.Errorf("Expected files %v, but got %v", tc.expectedFiles, files)
+			}
+		})
+	}
+}
+
+func TestSaveProject_MonorepoRoundTrip(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "orchestrator-test-config-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	services := []generator.Service{
+		{Name: "api", Path: "services/api", Archetype: string(detector.ArchetypePythonFastAPI), Data: generator.TemplateData{AppName: "demo-api", LanguageVersion: "3.12", Port: 8000}},
+		{Name: "web", Path: "services/web", Archetype: string(detector.ArchetypeNodeJSNextJS), Data: generator.TemplateData{AppName: "demo-web", LanguageVersion: "20", Port: 3000}},
+	}
+	data := generator.TemplateData{AppName: "demo", DatabaseType: "postgresql", DeploymentEnvironment: EnvironmentCloud, Services: services}
+	want := NewProjectConfig(detector.ProjectProfile{Archetype: detector.ArchetypeMonorepo}, data, generator.MonorepoFilesFor(services))
+	want.Services = []detector.ProjectProfile{
+		{Archetype: detector.ArchetypePythonFastAPI, LanguageVersion: "3.12", Path: "services/api"},
+		{Archetype: detector.ArchetypeNodeJSNextJS, LanguageVersion: "20", Path: "services/web"},
+	}
+
+	configPath := filepath.Join(tempDir, ProjectFileName)
+	if err := SaveProject(configPath, want); err != nil {
+		t.Fatalf("Did not expect an error saving, but got: %v", err)
+	}
+	got, err := LoadProject(configPath)
+	if err != nil {
+		t.Fatalf("Did not expect an error loading, but got: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Round trip mismatch:\n got: %+v\nwant: %+v", got, want)
+	}
+
+	for _, file := range got.Files {
+		if file.OutputPath == "services/web/Dockerfile" && got.Data.For(file).AppName != "demo-web" {
+			t.Errorf("Expected services/web/Dockerfile to render with demo-web, but got %s", got.Data.For(file).AppName)
+		}
+	}
+}
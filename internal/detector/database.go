@@ -0,0 +1,168 @@
+package detector
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Database types recorded in ProjectProfile.DatabaseType, matching the choices offered by init.
+const (
+	DatabaseMySQL      = "mysql"
+	DatabasePostgreSQL = "postgresql"
+	DatabaseMongoDB    = "mongodb"
+)
+
+// Database is a database the project's dependencies point to.
+type Database struct {
+	Type     string   `yaml:"type" json:"type"`
+	Evidence Evidence `yaml:"evidence" json:"evidence"`
+}
+
+// packageRule maps a package from a parsed manifest (Python or npm) to what it implies.
+type packageRule struct{ pkg, value string }
+
+// pythonDatabaseDrivers maps Python driver and ODM packages to the database they talk to.
+var pythonDatabaseDrivers = []packageRule{
+	{"psycopg", DatabasePostgreSQL}, {"psycopg-binary", DatabasePostgreSQL}, {"psycopg2", DatabasePostgreSQL},
+	{"psycopg2-binary", DatabasePostgreSQL}, {"asyncpg", DatabasePostgreSQL},
+	{"mysqlclient", DatabaseMySQL}, {"mysql-connector-python", DatabaseMySQL}, {"pymysql", DatabaseMySQL},
+	{"aiomysql", DatabaseMySQL}, {"asyncmy", DatabaseMySQL},
+	{"pymongo", DatabaseMongoDB}, {"motor", DatabaseMongoDB}, {"mongoengine", DatabaseMongoDB}, {"beanie", DatabaseMongoDB},
+}
+
+// nodeDatabaseDrivers maps npm driver and ODM packages to the database they talk to.
+var nodeDatabaseDrivers = []packageRule{
+	{"pg", DatabasePostgreSQL}, {"postgres", DatabasePostgreSQL}, {"pg-promise", DatabasePostgreSQL},
+	{"mysql", DatabaseMySQL}, {"mysql2", DatabaseMySQL},
+	{"mongodb", DatabaseMongoDB}, {"mongoose", DatabaseMongoDB},
+}
+
+// manifestRule matches a dependency line in a manifest that is not parsed
+// structurally. file may be a glob such as "*.csproj".
+type manifestRule struct {
+	file    string
+	pattern *regexp.Regexp
+	value   string
+}
+
+var databaseRules = []manifestRule{
+	// Maven and Gradle
+	{"pom.xml", regexp.MustCompile(`org\.postgresql`), DatabasePostgreSQL},
+	{"pom.xml", regexp.MustCompile(`mysql-connector|mariadb-java-client`), DatabaseMySQL},
+	{"pom.xml", regexp.MustCompile(`spring-boot-starter-data-mongodb|mongodb-driver`), DatabaseMongoDB},
+	{"build.gradle", regexp.MustCompile(`org\.postgresql`), DatabasePostgreSQL},
+	{"build.gradle", regexp.MustCompile(`mysql-connector|mariadb-java-client`), DatabaseMySQL},
+	{"build.gradle", regexp.MustCompile(`spring-boot-starter-data-mongodb|mongodb-driver`), DatabaseMongoDB},
+	{"build.gradle.kts", regexp.MustCompile(`org\.postgresql`), DatabasePostgreSQL},
+	{"build.gradle.kts", regexp.MustCompile(`mysql-connector|mariadb-java-client`), DatabaseMySQL},
+	{"build.gradle.kts", regexp.MustCompile(`spring-boot-starter-data-mongodb|mongodb-driver`), DatabaseMongoDB},
+	// Go modules
+	{"go.mod", regexp.MustCompile(`github\.com/lib/pq\b|github\.com/jackc/pgx`), DatabasePostgreSQL},
+	{"go.mod", regexp.MustCompile(`github\.com/go-sql-driver/mysql\b`), DatabaseMySQL},
+	{"go.mod", regexp.MustCompile(`go\.mongodb\.org/mongo-driver`), DatabaseMongoDB},
+	// Bundler
+	{"Gemfile", regexp.MustCompile(`^\s*gem\s+["']pg["']`), DatabasePostgreSQL},
+	{"Gemfile", regexp.MustCompile(`^\s*gem\s+["'](mysql2|trilogy)["']`), DatabaseMySQL},
+	{"Gemfile", regexp.MustCompile(`^\s*gem\s+["']mongoid["']`), DatabaseMongoDB},
+	// Composer
+	{"composer.json", regexp.MustCompile(`"(mongodb/laravel-mongodb|jenssegers/mongodb)"`), DatabaseMongoDB},
+	// Cargo
+	{"Cargo.toml", regexp.MustCompile(`^\s*(tokio-postgres|postgres)\s*=|^\s*(sqlx|diesel)\s*=.*"postgres"`), DatabasePostgreSQL},
+	{"Cargo.toml", regexp.MustCompile(`^\s*(mysql|mysql_async)\s*=|^\s*(sqlx|diesel)\s*=.*"mysql"`), DatabaseMySQL},
+	{"Cargo.toml", regexp.MustCompile(`^\s*mongodb\s*=`), DatabaseMongoDB},
+	// Mix
+	{"mix.exs", regexp.MustCompile(`\{:postgrex,`), DatabasePostgreSQL},
+	{"mix.exs", regexp.MustCompile(`\{:myxql,`), DatabaseMySQL},
+	{"mix.exs", regexp.MustCompile(`\{:mongodb_driver,`), DatabaseMongoDB},
+	// NuGet package references
+	{"*.csproj", regexp.MustCompile(`Include="Npgsql`), DatabasePostgreSQL},
+	{"*.csproj", regexp.MustCompile(`Include="(MySql\.Data|MySqlConnector|Pomelo\.EntityFrameworkCore\.MySql)`), DatabaseMySQL},
+	{"*.csproj", regexp.MustCompile(`Include="MongoDB\.Driver`), DatabaseMongoDB},
+}
+
+var (
+	// prismaProviderPattern matches the datasource provider in a Prisma schema.
+	prismaProviderPattern = regexp.MustCompile(`^\s*provider\s*=\s*"(postgresql|mysql|mongodb)"`)
+	// laravelDBConnectionPattern matches the default connection in a Laravel .env.example.
+	laravelDBConnectionPattern = regexp.MustCompile(`^DB_CONNECTION=(\w+)`)
+	// laravelConnections maps Laravel connection names to database types; sqlite needs no server.
+	laravelConnections = map[string]string{"pgsql": DatabasePostgreSQL, "mysql": DatabaseMySQL, "mariadb": DatabaseMySQL, "mongodb": DatabaseMongoDB}
+)
+
+// setDatabases records the databases inferred from dirPath's dependencies and
+// picks the first one as the profile's DatabaseType.
+func setDatabases(profile *ProjectProfile, dirPath string) {
+	profile.Databases = inferDatabases(dirPath)
+	if len(profile.Databases) > 0 && profile.DatabaseType == "" {
+		first := profile.Databases[0]
+		profile.DatabaseType = first.Type
+		source := first.Evidence.Detail
+		if first.Evidence.Line > 0 {
+			source = fmt.Sprintf("%s:%d: %s", first.Evidence.File, first.Evidence.Line, first.Evidence.Detail)
+		}
+		profile.setSource("database_type", source)
+	}
+}
+
+// inferDatabases returns each database the project's manifests point to, once,
+// in the order the evidence was found.
+func inferDatabases(dirPath string) []Database {
+	var databases []Database
+	add := func(database string, e Evidence) {
+		for _, existing := range databases {
+			if existing.Type == database {
+				return
+			}
+		}
+		databases = append(databases, Database{Type: database, Evidence: e})
+	}
+
+	// An explicit connection setting beats a driver that may only be there for tests.
+	if line, text := findLine(filepath.Join(dirPath, ".env.example"), laravelDBConnectionPattern.MatchString); line > 0 {
+		if database, ok := laravelConnections[laravelDBConnectionPattern.FindStringSubmatch(text)[1]]; ok {
+			add(database, Evidence{File: ".env.example", Line: line, Detail: text})
+		}
+	}
+	if line, text := findLine(filepath.Join(dirPath, "prisma", "schema.prisma"), prismaProviderPattern.MatchString); line > 0 {
+		add(prismaProviderPattern.FindStringSubmatch(text)[1], Evidence{File: "prisma/schema.prisma", Line: line, Detail: text})
+	}
+
+	matchDependencies(dirPath, pythonDatabaseDrivers, nodeDatabaseDrivers, databaseRules, add)
+	return databases
+}
+
+// matchDependencies calls add for every rule matched by dirPath's Python and
+// npm dependencies and by lines of its other manifests, in rule order.
+func matchDependencies(dirPath string, python, node []packageRule, manifests []manifestRule, add func(value string, e Evidence)) {
+	if project := parsePythonProject(dirPath); project != nil {
+		for _, rule := range python {
+			if e, ok := project.Has(rule.pkg); ok {
+				add(rule.value, e)
+			}
+		}
+	}
+	if pkg, err := readPackageJSON(dirPath); err == nil {
+		for _, rule := range node {
+			if e, ok := pkg.dependency(rule.pkg); ok {
+				add(rule.value, e)
+			}
+		}
+	}
+
+	for _, rule := range manifests {
+		files := []string{rule.file}
+		if strings.Contains(rule.file, "*") {
+			files, _ = filepath.Glob(filepath.Join(dirPath, rule.file))
+			for i, file := range files {
+				files[i] = filepath.Base(file)
+			}
+		}
+		for _, file := range files {
+			if line, text := findLine(filepath.Join(dirPath, file), rule.pattern.MatchString); line > 0 {
+				add(rule.value, Evidence{File: file, Line: line, Detail: text})
+			}
+		}
+	}
+}
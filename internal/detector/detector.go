@@ -1,13 +1,10 @@
 package detector
 
 import (
-	"encoding/json" // NEW
 	"fmt"
 	"os"
-	"path/filepath"
-	"strings" // NEW
-
-	"github.com/Masterminds/semver" // NEW
+	"sort"
+	"strings"
 )
 
 // Archetype represents the detected type of the project.
@@ -19,118 +16,195 @@ const (
 	ArchetypeJavaSpringBoot Archetype = "java_spring_boot"
 	ArchetypePythonFastAPI  Archetype = "python_fastapi"
 	// --- NEW ARYCHETYPES ---
-	ArchetypePHPLaravel     Archetype = "php_laravel"
-	ArchetypeNodeJSNextJS   Archetype = "nodejs_nextjs"
+	ArchetypePHPLaravel    Archetype = "php_laravel"
+	ArchetypeNodeJSNextJS  Archetype = "nodejs_nextjs"
+	ArchetypeGoService     Archetype = "go_service"
+	ArchetypeNodeJSServer  Archetype = "nodejs_server"
+	ArchetypeNodeJSSSR     Archetype = "nodejs_ssr"
+	ArchetypeNodeJSSPA     Archetype = "nodejs_spa"
+	ArchetypePythonDjango  Archetype = "python_django"
+	ArchetypePythonFlask   Archetype = "python_flask"
+	ArchetypeRubyRails     Archetype = "ruby_rails"
+	ArchetypeDotnetAspNet  Archetype = "dotnet_aspnet"
+	ArchetypeRustService   Archetype = "rust_service"
+	ArchetypeElixirPhoenix Archetype = "elixir_phoenix"
+	// ArchetypeMonorepo labels a repository of several services found by
+	// Discover. No detector produces it.
+	ArchetypeMonorepo Archetype = "monorepo"
 )
 
 // ProjectProfile struct
 type ProjectProfile struct {
-	Archetype           Archetype
-	LanguageVersion     string // e.g., "8.2", "18", "3.10"
-	DatabaseType        string
-	DeploymentEnvironment string
+	Archetype             Archetype `yaml:"archetype" json:"archetype"`
+	LanguageVersion       string    `yaml:"language_version" json:"language_version"` // e.g., "8.2", "18", "3.10"
+	DatabaseType          string    `yaml:"database_type,omitempty" json:"database_type,omitempty"`
+	DeploymentEnvironment string    `yaml:"deployment_environment,omitempty" json:"deployment_environment,omitempty"`
+	Framework             string    `yaml:"framework,omitempty" json:"framework,omitempty"`   // e.g., "gin", "nestjs", "vite"
+	Entrypoint            string    `yaml:"entrypoint,omitempty" json:"entrypoint,omitempty"` // e.g., "./cmd/server"
+	BuildTool             string    `yaml:"build_tool,omitempty" json:"build_tool,omitempty"` // e.g., "maven", "gradle"
+	BuildToolWrapper      bool      `yaml:"build_tool_wrapper,omitempty" json:"build_tool_wrapper,omitempty"`
+	PackageManager        string    `yaml:"package_manager,omitempty" json:"package_manager,omitempty"` // e.g., "poetry", "pnpm"
+	// PackageManagerVersion is the major version of the package manager when it matters, e.g. "4" for Yarn Berry.
+	PackageManagerVersion string `yaml:"package_manager_version,omitempty" json:"package_manager_version,omitempty"`
+	// NoLockfile is set when the project checks in no lockfile (or requirements.txt), so the
+	// build resolves its dependencies instead of installing pinned ones.
+	NoLockfile bool `yaml:"no_lockfile,omitempty" json:"no_lockfile,omitempty"`
+	// AssemblyName is the .NET assembly the container runs, e.g. "Api" for Api.dll.
+	AssemblyName string `yaml:"assembly_name,omitempty" json:"assembly_name,omitempty"`
+	// RuntimeVersion is the version of the runtime beneath the language when it
+	// is released separately, e.g. "27" for Erlang/OTP under Elixir.
+	RuntimeVersion string `yaml:"runtime_version,omitempty" json:"runtime_version,omitempty"`
+	// MigrationCommand runs the database migrations from the built image, if the project has them.
+	MigrationCommand string `yaml:"migration_command,omitempty" json:"migration_command,omitempty"`
+	// Databases are the databases inferred from the project's dependencies, in
+	// the order they were found; DatabaseType is the first of them.
+	Databases []Database `yaml:"databases,omitempty" json:"databases,omitempty"`
+	// BackingServices are the caches, queues and search engines inferred from the project's dependencies.
+	BackingServices []BackingService `yaml:"backing_services,omitempty" json:"backing_services,omitempty"`
+	// EnvVars are the environment variables the app reads its configuration from.
+	EnvVars []EnvVar `yaml:"env_vars,omitempty" json:"env_vars,omitempty"`
+	// Port is the port the app listens on inside its container.
+	Port int `yaml:"port,omitempty" json:"port,omitempty"`
+	// HealthPath is the HTTP path probes should check, e.g. "/healthz"; empty when none was found.
+	HealthPath string `yaml:"health_path,omitempty" json:"health_path,omitempty"`
+	// Path is the service directory relative to the repository root, set by Discover.
+	Path string `yaml:"path,omitempty" json:"path,omitempty"`
+	// Sources records where each inferred value came from, keyed by field name (e.g. "language_version").
+	Sources map[string]string `yaml:"sources,omitempty" json:"sources,omitempty"`
 }
 
-// fileExists is a helper function to check if a file exists at a given path.
-func fileExists(path string) bool {
-	info, err := os.Stat(path)
-	if os.IsNotExist(err) {
-		return false
+// setSource records where an inferred field's value came from.
+func (p *ProjectProfile) setSource(field, source string) {
+	if p.Sources == nil {
+		p.Sources = map[string]string{}
 	}
-	return !info.IsDir()
+	p.Sources[field] = source
 }
 
-// dirExists is a helper function to check if a directory exists.
-func dirExists(path string) bool {
-	info, err := os.Stat(path)
-	if os.IsNotExist(err) {
-		return false
-	}
-	return info.IsDir()
+// Evidence is one observation that made a detector match, e.g. a file that
+// exists or a dependency declared in a manifest.
+type Evidence struct {
+	File   string `yaml:"file" json:"file"`
+	Line   int    `yaml:"line,omitempty" json:"line,omitempty"` // 1-based, 0 when the whole file is the evidence
+	Detail string `yaml:"detail" json:"detail"`
 }
 
-// GetProjectProfile scans a directory to identify the project's archetype and language version.
-func GetProjectProfile(dirPath string) (*ProjectProfile, error) {
-	// --- PHP Laravel Detection ---
-	composerPath := filepath.Join(dirPath, "composer.json")
-	if fileExists(composerPath) && fileExists(filepath.Join(dirPath, "artisan")) {
-		version, err := parsePhpVersionFromComposer(composerPath)
-		if err != nil {
-			// Could not parse, but it's still a PHP project. Fallback to a default.
-			return &ProjectProfile{Archetype: ArchetypePHPLaravel, LanguageVersion: "8.2"}, nil
-		}
-		return &ProjectProfile{Archetype: ArchetypePHPLaravel, LanguageVersion: version}, nil
-	}
+// Detector recognises a single archetype.
+//
+// Detect inspects dir and returns the profile it would produce, a confidence
+// between 0 and 1, and the evidence behind it. A nil profile means no match.
+type Detector interface {
+	Name() string
+	Detect(dir string) (*ProjectProfile, float64, []Evidence)
+}
 
-	// --- Java Spring Boot Detection ---
-	if fileExists(filepath.Join(dirPath, "pom.xml")) || fileExists(filepath.Join(dirPath, "build.gradle")) {
-		return &ProjectProfile{Archetype: ArchetypeJavaSpringBoot, LanguageVersion: "17"}, nil // Default to Java 17
-	}
+// Match is the result of one detector matching a directory.
+type Match struct {
+	Detector   string          `yaml:"detector" json:"detector"`
+	Profile    *ProjectProfile `yaml:"profile" json:"profile"`
+	Confidence float64         `yaml:"confidence" json:"confidence"`
+	Evidence   []Evidence      `yaml:"evidence" json:"evidence"`
+}
 
-	// --- Python FastAPI Detection ---
-	if fileExists(filepath.Join(dirPath, "requirements.txt")) {
-		content, err := os.ReadFile(filepath.Join(dirPath, "requirements.txt"))
-		if err == nil && (strings.Contains(string(content), "fastapi") || strings.Contains(string(content), "FastAPI")) {
-			return &ProjectProfile{Archetype: ArchetypePythonFastAPI, LanguageVersion: "3.9"}, nil // Default to Python 3.9
-		}
-	}
+// CloseScoreMargin is how near two confidences must be for the match to be
+// considered ambiguous, so the user should be asked to choose.
+const CloseScoreMargin = 0.15
 
-	// --- NodeJS NextJS Detection ---
-	packageJSONPath := filepath.Join(dirPath, "package.json")
-	if fileExists(packageJSONPath) {
-		content, err := os.ReadFile(packageJSONPath)
-		if err == nil && strings.Contains(string(content), "\"next\"") {
-			return &ProjectProfile{Archetype: ArchetypeNodeJSNextJS, LanguageVersion: "18"}, nil // Default to Node 18
-		}
-	}
+var registry []Detector
 
-	return nil, fmt.Errorf("could not determine project type")
+// Register adds a detector to the registry. Packages providing new archetypes
+// call it from an init function.
+func Register(d Detector) {
+	registry = append(registry, d)
 }
 
-// composer.json structure for PHP version
-type Composer struct {
-	Require struct {
-		Php string `json:"php"`
-	} `json:"require"`
+// Detectors returns the registered detectors in registration order.
+func Detectors() []Detector {
+	return append([]Detector(nil), registry...)
 }
 
-func parsePhpVersionFromComposer(composerPath string) (string, error) {
-	data, err := os.ReadFile(composerPath)
-	if err != nil {
-		return "", fmt.Errorf("failed to read composer.json: %w", err)
+func init() {
+	Register(laravelDetector{})
+	Register(springBootDetector{})
+	Register(fastAPIDetector{})
+	Register(nextJSDetector{})
+	Register(goDetector{})
+	Register(nodeServerDetector{})
+	Register(nodeSSRDetector{})
+	Register(nodeSPADetector{})
+	Register(djangoDetector{})
+	Register(flaskDetector{})
+	Register(railsDetector{})
+	Register(aspNetDetector{})
+	Register(rustDetector{})
+	Register(phoenixDetector{})
+}
+
+// DetectAll runs every registered detector against dirPath and returns the
+// matches, best first. Ties keep registration order.
+func DetectAll(dirPath string) []Match {
+	var matches []Match
+	for _, d := range registry {
+		profile, confidence, evidence := d.Detect(dirPath)
+		if profile == nil {
+			continue
+		}
+		setDatabases(profile, dirPath)
+		setBackingServices(profile, dirPath)
+		setEndpoint(profile, dirPath)
+		setEnvVars(profile, dirPath)
+		matches = append(matches, Match{Detector: d.Name(), Profile: profile, Confidence: confidence, Evidence: evidence})
 	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Confidence > matches[j].Confidence
+	})
+	return matches
+}
 
-	var composer Composer
-	if err := json.Unmarshal(data, &composer); err != nil {
-		return "", fmt.Errorf("failed to parse composer.json: %w", err)
+// Ambiguous reports whether the best matches are too close to pick one automatically.
+func Ambiguous(matches []Match) bool {
+	return len(matches) > 1 && matches[0].Confidence-matches[1].Confidence < CloseScoreMargin
+}
+
+// GetProjectProfile scans a directory to identify the project's archetype and language version.
+// It returns the highest scoring match.
+func GetProjectProfile(dirPath string) (*ProjectProfile, error) {
+	matches := DetectAll(dirPath)
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("could not determine project type")
 	}
+	return matches[0].Profile, nil
+}
 
-	if composer.Require.Php == "" {
-		return "", fmt.Errorf("php version not found in composer.json 'require' section")
+// fileExists is a helper function to check if a file exists at a given path.
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return false
 	}
+	return !info.IsDir()
+}
 
-	// Use Masterminds/semver to parse the version constraint
-	// For simplicity, we'll try to find a compatible major.minor version.
-	// This is a simplified logic and might need more sophistication for real-world scenarios.
-	constraint, err := semver.NewConstraint(composer.Require.Php)
+// findLine returns the first line of the file at path for which match is true,
+// with its 1-based number. It returns 0 when no line matches or the file cannot be read.
+func findLine(path string, match func(line string) bool) (int, string) {
+	content, err := os.ReadFile(path)
 	if err != nil {
-		return "", fmt.Errorf("invalid PHP version constraint in composer.json: %w", err)
+		return 0, ""
 	}
-
-	// Define a list of common PHP versions to check against
-	// In a real scenario, this might come from a configuration or a more dynamic source
-	phpVersions := []string{"7.4.0", "8.0.0", "8.1.0", "8.2.0", "8.3.0"}
-	
-	for _, v := range phpVersions {
-		version, err := semver.NewVersion(v)
-		if err != nil {
-			continue // Skip invalid versions in our list
-		}
-		if constraint.Check(version) {
-			// Return major.minor version
-			return fmt.Sprintf("%d.%d", version.Major(), version.Minor()), nil
+	for i, line := range strings.Split(string(content), "\n") {
+		if match(line) {
+			return i + 1, strings.TrimSpace(line)
 		}
 	}
+	return 0, ""
+}
 
-	return "", fmt.Errorf("no compatible PHP version found for constraint %s", composer.Require.Php)
-}
\ No newline at end of file
+// dirExists is a helper function to check if a directory exists.
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return false
+	}
+	return info.IsDir()
+}
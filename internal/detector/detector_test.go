@@ -3,6 +3,7 @@ package detector
 import (
 "os"
 "path/filepath"
+"strings"
 "testing"
 )
 
@@ -175,4 +176,1466 @@ func TestGetProjectProfile_NodeJSNextJS(t *testing.T) {
 	if profile.LanguageVersion != "18" {
 		t.Errorf("Expected language version %s, but got %s", "18", profile.LanguageVersion)
 	}
-}
\ No newline at end of file
+}
+
+func TestDetectAll_LaravelWithNextFrontend(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "orchestrator-test-multi-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// A Laravel app that also ships a Next.js frontend from the same root.
+	os.WriteFile(filepath.Join(tempDir, "composer.json"), []byte(`{"require": {"php": "^8.2", "laravel/framework": "^10.0"}}`), 0644)
+	os.WriteFile(filepath.Join(tempDir, "artisan"), []byte(""), 0644)
+	os.WriteFile(filepath.Join(tempDir, "package.json"), []byte(`{"dependencies": {"next": "^14.0.0"}}`), 0644)
+
+	matches := DetectAll(tempDir)
+	if len(matches) != 2 {
+		t.Fatalf("Expected 2 matches, but got %d", len(matches))
+	}
+	if matches[0].Profile.Archetype != ArchetypePHPLaravel {
+		t.Errorf("Expected best match %s, but got %s", ArchetypePHPLaravel, matches[0].Profile.Archetype)
+	}
+	if matches[1].Profile.Archetype != ArchetypeNodeJSNextJS {
+		t.Errorf("Expected second match %s, but got %s", ArchetypeNodeJSNextJS, matches[1].Profile.Archetype)
+	}
+	if !Ambiguous(matches) {
+		t.Errorf("Expected close scores to be reported as ambiguous")
+	}
+	if len(matches[0].Evidence) == 0 {
+		t.Errorf("Expected evidence for the %s match", matches[0].Detector)
+	}
+}
+
+// customDetector stands in for an archetype registered by another package.
+type customDetector struct{}
+
+func (customDetector) Name() string { return "custom" }
+
+func (customDetector) Detect(dirPath string) (*ProjectProfile, float64, []Evidence) {
+	if !fileExists(filepath.Join(dirPath, "custom.toml")) {
+		return nil, 0, nil
+	}
+	return &ProjectProfile{Archetype: "custom_archetype", LanguageVersion: "1"}, 1, []Evidence{{File: "custom.toml", Detail: "custom.toml present"}}
+}
+
+func TestRegister_CustomDetector(t *testing.T) {
+	saved := registry
+	defer func() { registry = saved }()
+	Register(customDetector{})
+
+	tempDir, err := os.MkdirTemp("", "orchestrator-test-custom-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+	os.WriteFile(filepath.Join(tempDir, "custom.toml"), []byte(""), 0644)
+
+	profile, err := GetProjectProfile(tempDir)
+	if err != nil {
+		t.Fatalf("Did not expect an error, but got: %v", err)
+	}
+	if profile.Archetype != "custom_archetype" {
+		t.Errorf("Expected archetype %s, but got %s", "custom_archetype", profile.Archetype)
+	}
+}
+
+func TestGetProjectProfile_GoService(t *testing.T) {
+	testCases := []struct {
+		name               string
+		goModContent       string
+		mainPath           string
+		expectedVersion    string
+		expectedFramework  string
+		expectedEntrypoint string
+	}{
+		{
+			name:               "Standard library service",
+			goModContent:       "module example.com/api\n\ngo 1.22.3\n",
+			mainPath:           "main.go",
+			expectedVersion:    "1.22",
+			expectedFramework:  "net/http",
+			expectedEntrypoint: ".",
+		},
+		{
+			name: "Gin service under cmd/",
+			goModContent: `module example.com/api
+
+go 1.23
+
+require (
+	github.com/gin-gonic/gin v1.10.0 // indirect comment
+	golang.org/x/sync v0.7.0
+)
+`,
+			mainPath:           "cmd/server/main.go",
+			expectedVersion:    "1.23",
+			expectedFramework:  "gin",
+			expectedEntrypoint: "./cmd/server",
+		},
+		{
+			name:               "Echo v4 with a single-line require",
+			goModContent:       "module example.com/api\n\ngo 1.21\n\nrequire github.com/labstack/echo/v4 v4.12.0\n",
+			mainPath:           "main.go",
+			expectedVersion:    "1.21",
+			expectedFramework:  "echo",
+			expectedEntrypoint: ".",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tempDir, err := os.MkdirTemp("", "orchestrator-test-go-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			os.WriteFile(filepath.Join(tempDir, "go.mod"), []byte(tc.goModContent), 0644)
+			os.MkdirAll(filepath.Dir(filepath.Join(tempDir, tc.mainPath)), 0755)
+			os.WriteFile(filepath.Join(tempDir, tc.mainPath), []byte("package main\n\nfunc main() {}\n"), 0644)
+
+			profile, err := GetProjectProfile(tempDir)
+			if err != nil {
+				t.Fatalf("Did not expect an error, but got: %v", err)
+			}
+			if profile.Archetype != ArchetypeGoService {
+				t.Errorf("Expected archetype %s, but got %s", ArchetypeGoService, profile.Archetype)
+			}
+			if profile.LanguageVersion != tc.expectedVersion {
+				t.Errorf("Expected language version %s, but got %s", tc.expectedVersion, profile.LanguageVersion)
+			}
+			if profile.Framework != tc.expectedFramework {
+				t.Errorf("Expected framework %s, but got %s", tc.expectedFramework, profile.Framework)
+			}
+			if profile.Entrypoint != tc.expectedEntrypoint {
+				t.Errorf("Expected entrypoint %s, but got %s", tc.expectedEntrypoint, profile.Entrypoint)
+			}
+		})
+	}
+}
+
+func TestGetProjectProfile_JavaVersionAndBuildTool(t *testing.T) {
+	testCases := []struct {
+		name              string
+		files             map[string]string
+		expectedVersion   string
+		expectedBuildTool string
+		expectedWrapper   bool
+	}{
+		{
+			name: "Maven release property",
+			files: map[string]string{
+				"pom.xml": `<project><properties><maven.compiler.release>21</maven.compiler.release></properties></project>`,
+			},
+			expectedVersion:   "21",
+			expectedBuildTool: BuildToolMaven,
+		},
+		{
+			name: "Maven java.version referenced from another property, with wrapper",
+			files: map[string]string{
+				"pom.xml": `<project><properties><jdk>1.8</jdk><java.version>${jdk}</java.version></properties></project>`,
+				"mvnw":    "",
+			},
+			expectedVersion:   "8",
+			expectedBuildTool: BuildToolMaven,
+			expectedWrapper:   true,
+		},
+		{
+			name: "Maven compiler plugin source",
+			files: map[string]string{
+				"pom.xml": `<project><build><plugins><plugin>
+					<artifactId>maven-compiler-plugin</artifactId>
+					<configuration><source>11</source></configuration>
+				</plugin></plugins></build></project>`,
+			},
+			expectedVersion:   "11",
+			expectedBuildTool: BuildToolMaven,
+		},
+		{
+			name: "Gradle Kotlin DSL toolchain with wrapper",
+			files: map[string]string{
+				"build.gradle.kts": "java {\n    toolchain {\n        languageVersion.set(JavaLanguageVersion.of(21))\n    }\n}\n",
+				"gradlew":          "",
+			},
+			expectedVersion:   "21",
+			expectedBuildTool: BuildToolGradle,
+			expectedWrapper:   true,
+		},
+		{
+			name: "Gradle Groovy sourceCompatibility",
+			files: map[string]string{
+				"build.gradle": "plugins { id 'org.springframework.boot' version '3.2.0' }\nsourceCompatibility = JavaVersion.VERSION_17\n",
+			},
+			expectedVersion:   "17",
+			expectedBuildTool: BuildToolGradle,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tempDir, err := os.MkdirTemp("", "orchestrator-test-java-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			for name, content := range tc.files {
+				os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644)
+			}
+
+			profile, err := GetProjectProfile(tempDir)
+			if err != nil {
+				t.Fatalf("Did not expect an error, but got: %v", err)
+			}
+			if profile.LanguageVersion != tc.expectedVersion {
+				t.Errorf("Expected language version %s, but got %s", tc.expectedVersion, profile.LanguageVersion)
+			}
+			if profile.BuildTool != tc.expectedBuildTool {
+				t.Errorf("Expected build tool %s, but got %s", tc.expectedBuildTool, profile.BuildTool)
+			}
+			if profile.BuildToolWrapper != tc.expectedWrapper {
+				t.Errorf("Expected wrapper %v, but got %v", tc.expectedWrapper, profile.BuildToolWrapper)
+			}
+		})
+	}
+}
+
+func TestGetProjectProfile_PythonVersion(t *testing.T) {
+	testCases := []struct {
+		name            string
+		files           map[string]string
+		expectedVersion string
+	}{
+		{
+			name:            ".python-version wins over everything else",
+			files:           map[string]string{".python-version": "3.12.1\n", "runtime.txt": "python-3.10.4"},
+			expectedVersion: "3.12",
+		},
+		{
+			name:            "pyproject requires-python lower bound",
+			files:           map[string]string{"pyproject.toml": "[project]\nname = \"api\"\nrequires-python = \">=3.10\"\n"},
+			expectedVersion: "3.10",
+		},
+		{
+			name:            "pyproject requires-python range",
+			files:           map[string]string{"pyproject.toml": "[project]\nrequires-python = \">=3.9,!=3.9.*,<3.13\"\n"},
+			expectedVersion: "3.10",
+		},
+		{
+			name:            "runtime.txt",
+			files:           map[string]string{"runtime.txt": "python-3.11.4\n"},
+			expectedVersion: "3.11",
+		},
+		{
+			name:            "Pipfile python_version",
+			files:           map[string]string{"Pipfile": "[packages]\nfastapi = \"*\"\n\n[requires]\npython_version = \"3.11\"\n"},
+			expectedVersion: "3.11",
+		},
+		{
+			name:            "setup.cfg python_requires",
+			files:           map[string]string{"setup.cfg": "[options]\npython_requires = >=3.8\n"},
+			expectedVersion: "3.8",
+		},
+		{
+			name:            "Nothing declared",
+			files:           map[string]string{},
+			expectedVersion: "3.9",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tempDir, err := os.MkdirTemp("", "orchestrator-test-python-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			os.WriteFile(filepath.Join(tempDir, "requirements.txt"), []byte("fastapi\n"), 0644)
+			for name, content := range tc.files {
+				os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644)
+			}
+
+			profile, err := GetProjectProfile(tempDir)
+			if err != nil {
+				t.Fatalf("Did not expect an error, but got: %v", err)
+			}
+			if profile.LanguageVersion != tc.expectedVersion {
+				t.Errorf("Expected language version %s, but got %s", tc.expectedVersion, profile.LanguageVersion)
+			}
+		})
+	}
+}
+
+func TestGetProjectProfile_PythonPackageManager(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		files                  map[string]string
+		expectedPackageManager string
+		expectedNoLockfile     bool
+	}{
+		{
+			name:                   "requirements.txt with extras and comments",
+			files:                  map[string]string{"requirements.txt": "# web\n-r base.txt\nFastAPI[all]>=0.110 # api\nuvicorn\n"},
+			expectedPackageManager: PackageManagerPip,
+		},
+		{
+			name:                   "Poetry project with lockfile",
+			files:                  map[string]string{"pyproject.toml": "[tool.poetry]\nname = \"api\"\n\n[tool.poetry.dependencies]\npython = \"^3.11\"\nfastapi = \"^0.110\"\n", "poetry.lock": ""},
+			expectedPackageManager: PackageManagerPoetry,
+		},
+		{
+			name:                   "uv project with PEP 621 dependencies",
+			files:                  map[string]string{"pyproject.toml": "[project]\nname = \"api\"\ndependencies = [\n    \"fastapi>=0.110\",\n    \"uvicorn[standard]\",\n]\n", "uv.lock": ""},
+			expectedPackageManager: PackageManagerUV,
+		},
+		{
+			name:                   "Pipenv project",
+			files:                  map[string]string{"Pipfile": "[packages]\nfastapi = \"*\"\n\n[dev-packages]\npytest = \"*\"\n"},
+			expectedPackageManager: PackageManagerPipenv,
+		},
+		{
+			name:                   "PDM project with inline dependencies",
+			files:                  map[string]string{"pyproject.toml": "[project]\ndependencies = [\"uvicorn\", \"fastapi\"]\n\n[tool.pdm]\n", "pdm.lock": ""},
+			expectedPackageManager: PackageManagerPDM,
+		},
+		{
+			name:                   "pyproject.toml without a lockfile",
+			files:                  map[string]string{"pyproject.toml": "[project]\nname = \"api\"\ndependencies = [\"fastapi\"]\n"},
+			expectedPackageManager: PackageManagerPip,
+			expectedNoLockfile:     true,
+		},
+		{
+			name:                   "uv section without uv.lock",
+			files:                  map[string]string{"pyproject.toml": "[project]\ndependencies = [\"fastapi\"]\n\n[tool.uv]\ndev-dependencies = []\n"},
+			expectedPackageManager: PackageManagerPip,
+			expectedNoLockfile:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tempDir, err := os.MkdirTemp("", "orchestrator-test-python-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			for name, content := range tc.files {
+				os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644)
+			}
+
+			profile, err := GetProjectProfile(tempDir)
+			if err != nil {
+				t.Fatalf("Did not expect an error, but got: %v", err)
+			}
+			if profile.Archetype != ArchetypePythonFastAPI {
+				t.Errorf("Expected archetype %s, but got %s", ArchetypePythonFastAPI, profile.Archetype)
+			}
+			if profile.PackageManager != tc.expectedPackageManager {
+				t.Errorf("Expected package manager %s, but got %s", tc.expectedPackageManager, profile.PackageManager)
+			}
+			if profile.NoLockfile != tc.expectedNoLockfile {
+				t.Errorf("Expected NoLockfile %t, but got %t", tc.expectedNoLockfile, profile.NoLockfile)
+			}
+		})
+	}
+}
+
+func TestGetProjectProfile_PythonDependencyIsNotSubstring(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "orchestrator-test-python-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	os.WriteFile(filepath.Join(tempDir, "requirements.txt"), []byte("fastapi-utils\n# fastapi is not used here\n"), 0644)
+
+	_, err = GetProjectProfile(tempDir)
+	if err == nil {
+		t.Errorf("Expected an error for a project without fastapi, but got nil")
+	}
+}
+
+func TestGetProjectProfile_NodeVersionAndPackageManager(t *testing.T) {
+	testCases := []struct {
+		name                          string
+		packageJSON                   string
+		files                         map[string]string
+		expectedVersion               string
+		expectedPackageManager        string
+		expectedPackageManagerVersion string
+		expectedNoLockfile            bool
+	}{
+		{
+			name:                   "Defaults without lockfile or version",
+			packageJSON:            `{"dependencies": {"next": "14.0.0"}}`,
+			expectedVersion:        "18",
+			expectedPackageManager: PackageManagerNPM,
+			expectedNoLockfile:     true,
+		},
+		{
+			name:                   "engines.node range and npm lockfile",
+			packageJSON:            `{"engines": {"node": ">=20.9.0"}, "dependencies": {"next": "14.0.0"}}`,
+			files:                  map[string]string{"package-lock.json": "{}"},
+			expectedVersion:        "20",
+			expectedPackageManager: PackageManagerNPM,
+		},
+		{
+			name:                   "caret engines.node",
+			packageJSON:            `{"engines": {"node": "^22.11.0"}, "dependencies": {"next": "15.0.0"}}`,
+			expectedVersion:        "22",
+			expectedPackageManager: PackageManagerNPM,
+			expectedNoLockfile:     true,
+		},
+		{
+			name:                   ".nvmrc wins over engines.node",
+			packageJSON:            `{"engines": {"node": ">=18"}, "dependencies": {"next": "14.0.0"}}`,
+			files:                  map[string]string{".nvmrc": "v20.11.1\n", "pnpm-lock.yaml": ""},
+			expectedVersion:        "20",
+			expectedPackageManager: PackageManagerPNPM,
+		},
+		{
+			name:                   ".nvmrc LTS alias",
+			packageJSON:            `{"dependencies": {"next": "14.0.0"}}`,
+			files:                  map[string]string{".nvmrc": "lts/iron\n", "yarn.lock": ""},
+			expectedVersion:        "20",
+			expectedPackageManager: PackageManagerYarn,
+		},
+		{
+			name:                   ".node-version and bun lockfile",
+			packageJSON:            `{"dependencies": {"next": "14.0.0"}}`,
+			files:                  map[string]string{".node-version": "22\n", "bun.lockb": ""},
+			expectedVersion:        "22",
+			expectedPackageManager: PackageManagerBun,
+		},
+		{
+			name:                          "packageManager field wins over lockfiles",
+			packageJSON:                   `{"packageManager": "yarn@4.1.0", "dependencies": {"next": "14.0.0"}}`,
+			files:                         map[string]string{"package-lock.json": "{}"},
+			expectedVersion:               "18",
+			expectedPackageManager:        PackageManagerYarn,
+			expectedPackageManagerVersion: "4",
+			expectedNoLockfile:            true,
+		},
+		{
+			name:                          "packageManager field without its lockfile",
+			packageJSON:                   `{"packageManager": "pnpm@9.1.0", "dependencies": {"next": "14.0.0"}}`,
+			expectedVersion:               "18",
+			expectedPackageManager:        PackageManagerPNPM,
+			expectedPackageManagerVersion: "9",
+			expectedNoLockfile:            true,
+		},
+		{
+			name:                          "Yarn Berry from yarnPath",
+			packageJSON:                   `{"dependencies": {"next": "14.0.0"}}`,
+			files:                         map[string]string{"yarn.lock": "", ".yarnrc.yml": "yarnPath: .yarn/releases/yarn-3.6.4.cjs\n"},
+			expectedVersion:               "18",
+			expectedPackageManager:        PackageManagerYarn,
+			expectedPackageManagerVersion: "3",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tempDir, err := os.MkdirTemp("", "orchestrator-test-node-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			os.WriteFile(filepath.Join(tempDir, "package.json"), []byte(tc.packageJSON), 0644)
+			for name, content := range tc.files {
+				os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644)
+			}
+
+			profile, err := GetProjectProfile(tempDir)
+			if err != nil {
+				t.Fatalf("Did not expect an error, but got: %v", err)
+			}
+			if profile.LanguageVersion != tc.expectedVersion {
+				t.Errorf("Expected language version %s, but got %s", tc.expectedVersion, profile.LanguageVersion)
+			}
+			if profile.PackageManager != tc.expectedPackageManager {
+				t.Errorf("Expected package manager %s, but got %s", tc.expectedPackageManager, profile.PackageManager)
+			}
+			if profile.PackageManagerVersion != tc.expectedPackageManagerVersion {
+				t.Errorf("Expected package manager version %q, but got %q", tc.expectedPackageManagerVersion, profile.PackageManagerVersion)
+			}
+			if profile.NoLockfile != tc.expectedNoLockfile {
+				t.Errorf("Expected NoLockfile %t, but got %t", tc.expectedNoLockfile, profile.NoLockfile)
+			}
+		})
+	}
+}
+
+func TestGetProjectProfile_NodeArchetypes(t *testing.T) {
+	testCases := []struct {
+		name               string
+		packageJSON        string
+		files              map[string]string
+		expectedArchetype  Archetype
+		expectedFramework  string
+		expectedEntrypoint string
+	}{
+		{
+			name:               "Express server with start script",
+			packageJSON:        "{\n  \"scripts\": {\n    \"start\": \"node server.js\"\n  },\n  \"dependencies\": {\n    \"express\": \"^4.19.0\"\n  }\n}",
+			expectedArchetype:  ArchetypeNodeJSServer,
+			expectedFramework:  "express",
+			expectedEntrypoint: "start",
+		},
+		{
+			name:               "Fastify server",
+			packageJSON:        `{"scripts": {"start": "node app.js"}, "dependencies": {"fastify": "^4.0.0"}}`,
+			expectedArchetype:  ArchetypeNodeJSServer,
+			expectedFramework:  "fastify",
+			expectedEntrypoint: "start",
+		},
+		{
+			name:               "NestJS prefers start:prod",
+			packageJSON:        `{"scripts": {"start": "nest start", "start:prod": "node dist/main"}, "dependencies": {"@nestjs/core": "^10.0.0", "express": "^4.19.0"}}`,
+			expectedArchetype:  ArchetypeNodeJSServer,
+			expectedFramework:  "nestjs",
+			expectedEntrypoint: "start:prod",
+		},
+		{
+			name:              "Nuxt",
+			packageJSON:       `{"dependencies": {"nuxt": "^3.10.0"}}`,
+			expectedArchetype: ArchetypeNodeJSSSR,
+			expectedFramework: "nuxt",
+		},
+		{
+			name:              "Remix",
+			packageJSON:       `{"dependencies": {"@remix-run/node": "^2.8.0", "@remix-run/react": "^2.8.0"}, "devDependencies": {"vite": "^5.0.0"}}`,
+			expectedArchetype: ArchetypeNodeJSSSR,
+			expectedFramework: "remix",
+		},
+		{
+			name:              "SvelteKit with adapter-node",
+			packageJSON:       `{"devDependencies": {"@sveltejs/kit": "^2.0.0", "@sveltejs/adapter-node": "^5.0.0", "vite": "^5.0.0"}}`,
+			expectedArchetype: ArchetypeNodeJSSSR,
+			expectedFramework: "sveltekit",
+		},
+		{
+			name:               "Vite SPA with custom outDir",
+			packageJSON:        `{"dependencies": {"react": "^18.0.0"}, "devDependencies": {"vite": "^5.0.0"}}`,
+			files:              map[string]string{"vite.config.ts": "export default defineConfig({\n  build: { outDir: 'public/app' },\n})\n"},
+			expectedArchetype:  ArchetypeNodeJSSPA,
+			expectedFramework:  "vite",
+			expectedEntrypoint: "public/app",
+		},
+		{
+			name:               "Create React App",
+			packageJSON:        `{"dependencies": {"react": "^18.0.0", "react-scripts": "5.0.1"}}`,
+			expectedArchetype:  ArchetypeNodeJSSPA,
+			expectedFramework:  "cra",
+			expectedEntrypoint: "build",
+		},
+		{
+			name:               "Angular application builder",
+			packageJSON:        `{"dependencies": {"@angular/core": "^17.0.0"}}`,
+			files:              map[string]string{"angular.json": `{"projects": {"shop": {"architect": {"build": {"builder": "@angular-devkit/build-angular:application", "options": {"outputPath": "dist/shop"}}}}}}`},
+			expectedArchetype:  ArchetypeNodeJSSPA,
+			expectedFramework:  "angular",
+			expectedEntrypoint: "dist/shop/browser",
+		},
+		{
+			name:               "Angular browser builder",
+			packageJSON:        `{"dependencies": {"@angular/core": "^15.0.0"}}`,
+			files:              map[string]string{"angular.json": `{"projects": {"admin": {"architect": {"build": {"builder": "@angular-devkit/build-angular:browser", "options": {"outputPath": "dist/admin"}}}}}}`},
+			expectedArchetype:  ArchetypeNodeJSSPA,
+			expectedFramework:  "angular",
+			expectedEntrypoint: "dist/admin",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tempDir, err := os.MkdirTemp("", "orchestrator-test-node-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			os.WriteFile(filepath.Join(tempDir, "package.json"), []byte(tc.packageJSON), 0644)
+			for name, content := range tc.files {
+				os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644)
+			}
+
+			profile, err := GetProjectProfile(tempDir)
+			if err != nil {
+				t.Fatalf("Did not expect an error, but got: %v", err)
+			}
+			if profile.Archetype != tc.expectedArchetype {
+				t.Errorf("Expected archetype %s, but got %s", tc.expectedArchetype, profile.Archetype)
+			}
+			if profile.Framework != tc.expectedFramework {
+				t.Errorf("Expected framework %s, but got %s", tc.expectedFramework, profile.Framework)
+			}
+			if profile.Entrypoint != tc.expectedEntrypoint {
+				t.Errorf("Expected entrypoint %q, but got %q", tc.expectedEntrypoint, profile.Entrypoint)
+			}
+		})
+	}
+}
+
+func TestDetectAll_NodeServerEvidence(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "orchestrator-test-node-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	os.WriteFile(filepath.Join(tempDir, "package.json"), []byte("{\n  \"dependencies\": {\n    \"express\": \"^4.19.0\"\n  }\n}"), 0644)
+
+	matches := DetectAll(tempDir)
+	if len(matches) != 1 {
+		t.Fatalf("Expected 1 match, but got %d", len(matches))
+	}
+	if matches[0].Evidence[0].Line != 3 {
+		t.Errorf("Expected the express dependency on line 3, but got line %d", matches[0].Evidence[0].Line)
+	}
+	if matches[0].Profile.Sources["entrypoint"] != "default (no start script in package.json)" {
+		t.Errorf("Expected the default entrypoint source, but got %q", matches[0].Profile.Sources["entrypoint"])
+	}
+}
+
+func TestGetProjectProfile_DjangoAndFlask(t *testing.T) {
+	testCases := []struct {
+		name               string
+		files              map[string]string
+		expectedArchetype  Archetype
+		expectedEntrypoint string
+	}{
+		{
+			name: "Django WSGI project",
+			files: map[string]string{
+				"requirements.txt":   "Django==5.0.3\npsycopg[binary]\n",
+				"manage.py":          "import os\n\ndef main():\n    os.environ.setdefault('DJANGO_SETTINGS_MODULE', 'mysite.settings')\n",
+				"mysite/settings.py": "DEBUG = False\n",
+				"mysite/wsgi.py":     "application = get_wsgi_application()\n",
+			},
+			expectedArchetype:  ArchetypePythonDjango,
+			expectedEntrypoint: "mysite.wsgi:application",
+		},
+		{
+			name: "Django ASGI project with channels",
+			files: map[string]string{
+				"pyproject.toml":     "[tool.poetry.dependencies]\npython = \"^3.12\"\ndjango = \"^5.0\"\nchannels = \"^4.0\"\n",
+				"manage.py":          "os.environ.setdefault(\"DJANGO_SETTINGS_MODULE\", \"config.settings\")\n",
+				"config/settings.py": "",
+				"config/wsgi.py":     "",
+				"config/asgi.py":     "",
+			},
+			expectedArchetype:  ArchetypePythonDjango,
+			expectedEntrypoint: "config.asgi:application",
+		},
+		{
+			name: "Django settings found without manage.py",
+			files: map[string]string{
+				"requirements.txt": "django\n",
+				"shop/settings.py": "",
+				"shop/wsgi.py":     "",
+			},
+			expectedArchetype:  ArchetypePythonDjango,
+			expectedEntrypoint: "shop.wsgi:application",
+		},
+		{
+			name: "Flask module-level app",
+			files: map[string]string{
+				"requirements.txt": "Flask>=3.0\n",
+				"app.py":           "from flask import Flask\n\nserver = Flask(__name__)\n",
+			},
+			expectedArchetype:  ArchetypePythonFlask,
+			expectedEntrypoint: "app:server",
+		},
+		{
+			name: "Flask application factory in a package",
+			files: map[string]string{
+				"Pipfile":              "[packages]\nflask = \"*\"\n",
+				"blog/__init__.py":     "from flask import Flask\n\ndef create_app(config=None):\n    return Flask(__name__)\n",
+				"blog/views/routes.py": "",
+			},
+			expectedArchetype:  ArchetypePythonFlask,
+			expectedEntrypoint: "blog:create_app()",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tempDir, err := os.MkdirTemp("", "orchestrator-test-python-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			for name, content := range tc.files {
+				os.MkdirAll(filepath.Dir(filepath.Join(tempDir, name)), os.ModePerm)
+				os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644)
+			}
+
+			profile, err := GetProjectProfile(tempDir)
+			if err != nil {
+				t.Fatalf("Did not expect an error, but got: %v", err)
+			}
+			if profile.Archetype != tc.expectedArchetype {
+				t.Errorf("Expected archetype %s, but got %s", tc.expectedArchetype, profile.Archetype)
+			}
+			if profile.Entrypoint != tc.expectedEntrypoint {
+				t.Errorf("Expected entrypoint %q, but got %q", tc.expectedEntrypoint, profile.Entrypoint)
+			}
+		})
+	}
+}
+
+func TestGetProjectProfile_RubyRails(t *testing.T) {
+	testCases := []struct {
+		name               string
+		files              map[string]string
+		expectedVersion    string
+		expectedConfidence float64
+	}{
+		{
+			name: ".ruby-version and config/application.rb",
+			files: map[string]string{
+				"Gemfile":               "source \"https://rubygems.org\"\ngem \"rails\", \"~> 7.1.3\"\n",
+				".ruby-version":         "ruby-3.2.2\n",
+				"config/application.rb": "module Shop\n  class Application < Rails::Application\n  end\nend\n",
+			},
+			expectedVersion:    "3.2",
+			expectedConfidence: 0.95,
+		},
+		{
+			name: "Gemfile ruby directive",
+			files: map[string]string{
+				"Gemfile": "ruby '3.1.4'\ngem 'rails'\n",
+			},
+			expectedVersion:    "3.1",
+			expectedConfidence: 0.75,
+		},
+		{
+			name: "Gemfile pessimistic ruby requirement",
+			files: map[string]string{
+				"Gemfile": "ruby \"~> 3.3.0\"\ngem \"rails\"\n",
+			},
+			expectedVersion:    "3.3",
+			expectedConfidence: 0.75,
+		},
+		{
+			name: "Gemfile.lock only",
+			files: map[string]string{
+				"Gemfile":      "gem \"railties\"\n",
+				"Gemfile.lock": "GEM\n  specs:\n    rails (7.0.8)\n\nRUBY VERSION\n   ruby 3.0.6p216\n",
+			},
+			expectedVersion:    "3.0",
+			expectedConfidence: 0.75,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tempDir, err := os.MkdirTemp("", "orchestrator-test-rails-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			for name, content := range tc.files {
+				os.MkdirAll(filepath.Dir(filepath.Join(tempDir, name)), os.ModePerm)
+				os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644)
+			}
+
+			matches := DetectAll(tempDir)
+			if len(matches) != 1 {
+				t.Fatalf("Expected 1 match, but got %d", len(matches))
+			}
+			if matches[0].Profile.Archetype != ArchetypeRubyRails {
+				t.Errorf("Expected archetype %s, but got %s", ArchetypeRubyRails, matches[0].Profile.Archetype)
+			}
+			if matches[0].Profile.LanguageVersion != tc.expectedVersion {
+				t.Errorf("Expected language version %s, but got %s", tc.expectedVersion, matches[0].Profile.LanguageVersion)
+			}
+			if matches[0].Confidence != tc.expectedConfidence {
+				t.Errorf("Expected confidence %.2f, but got %.2f", tc.expectedConfidence, matches[0].Confidence)
+			}
+		})
+	}
+}
+
+func TestGetProjectProfile_DotnetAspNet(t *testing.T) {
+	testCases := []struct {
+		name               string
+		files              map[string]string
+		expectedVersion    string
+		expectedEntrypoint string
+		expectedAssembly   string
+	}{
+		{
+			name: "Web project at the root",
+			files: map[string]string{
+				"Api.csproj": `<Project Sdk="Microsoft.NET.Sdk.Web"><PropertyGroup><TargetFramework>net8.0</TargetFramework></PropertyGroup></Project>`,
+			},
+			expectedVersion:    "8.0",
+			expectedEntrypoint: "Api.csproj",
+			expectedAssembly:   "Api",
+		},
+		{
+			name: "Solution with web and test projects",
+			files: map[string]string{
+				"Shop.sln":                           "",
+				"src/Shop.Core/Shop.Core.csproj":     `<Project Sdk="Microsoft.NET.Sdk"><PropertyGroup><TargetFramework>net9.0</TargetFramework></PropertyGroup></Project>`,
+				"src/Shop.Web/Shop.Web.csproj":       `<Project Sdk="Microsoft.NET.Sdk.Web"><PropertyGroup><TargetFramework>net9.0</TargetFramework><AssemblyName>shop</AssemblyName></PropertyGroup></Project>`,
+				"tests/Shop.Tests/Shop.Tests.csproj": `<Project Sdk="Microsoft.NET.Sdk"><PropertyGroup><TargetFramework>net9.0</TargetFramework></PropertyGroup></Project>`,
+			},
+			expectedVersion:    "9.0",
+			expectedEntrypoint: "src/Shop.Web/Shop.Web.csproj",
+			expectedAssembly:   "shop",
+		},
+		{
+			name: "Multi-targeting picks the newest runtime",
+			files: map[string]string{
+				"Api.csproj": `<Project Sdk="Microsoft.NET.Sdk.Web"><PropertyGroup><TargetFrameworks>net6.0;net10.0;net8.0</TargetFrameworks></PropertyGroup></Project>`,
+			},
+			expectedVersion:    "10.0",
+			expectedEntrypoint: "Api.csproj",
+			expectedAssembly:   "Api",
+		},
+		{
+			name: "Legacy .NET Core moniker",
+			files: map[string]string{
+				"Legacy.csproj": `<Project Sdk="Microsoft.NET.Sdk.Web"><PropertyGroup><TargetFramework>netcoreapp3.1</TargetFramework></PropertyGroup></Project>`,
+			},
+			expectedVersion:    "3.1",
+			expectedEntrypoint: "Legacy.csproj",
+			expectedAssembly:   "Legacy",
+		},
+		{
+			name: "ASP.NET Core framework reference without the Web SDK",
+			files: map[string]string{
+				"src/Shop.Core/Shop.Core.csproj": `<Project Sdk="Microsoft.NET.Sdk"><PropertyGroup><TargetFramework>net8.0</TargetFramework></PropertyGroup></Project>`,
+				"src/Shop.Host/Shop.Host.csproj": `<Project Sdk="Microsoft.NET.Sdk"><PropertyGroup><TargetFramework>net8.0</TargetFramework></PropertyGroup><ItemGroup><FrameworkReference Include="Microsoft.AspNetCore.App" /></ItemGroup></Project>`,
+			},
+			expectedVersion:    "8.0",
+			expectedEntrypoint: "src/Shop.Host/Shop.Host.csproj",
+			expectedAssembly:   "Shop.Host",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tempDir, err := os.MkdirTemp("", "orchestrator-test-dotnet-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			for name, content := range tc.files {
+				os.MkdirAll(filepath.Dir(filepath.Join(tempDir, name)), os.ModePerm)
+				os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644)
+			}
+
+			profile, err := GetProjectProfile(tempDir)
+			if err != nil {
+				t.Fatalf("Did not expect an error, but got: %v", err)
+			}
+			if profile.Archetype != ArchetypeDotnetAspNet {
+				t.Errorf("Expected archetype %s, but got %s", ArchetypeDotnetAspNet, profile.Archetype)
+			}
+			if profile.LanguageVersion != tc.expectedVersion {
+				t.Errorf("Expected language version %s, but got %s", tc.expectedVersion, profile.LanguageVersion)
+			}
+			if profile.Entrypoint != tc.expectedEntrypoint {
+				t.Errorf("Expected entrypoint %s, but got %s", tc.expectedEntrypoint, profile.Entrypoint)
+			}
+			if profile.AssemblyName != tc.expectedAssembly {
+				t.Errorf("Expected assembly name %s, but got %s", tc.expectedAssembly, profile.AssemblyName)
+			}
+		})
+	}
+}
+
+func TestGetProjectProfile_DotnetWithoutAspNet(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "orchestrator-test-dotnet-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	os.WriteFile(filepath.Join(tempDir, "Tool.csproj"), []byte(`<Project Sdk="Microsoft.NET.Sdk"><PropertyGroup><OutputType>Exe</OutputType><TargetFramework>net8.0</TargetFramework></PropertyGroup></Project>`), 0644)
+
+	if matches := DetectAll(tempDir); len(matches) != 0 {
+		t.Errorf("Expected a console app not to match, but got %s", matches[0].Detector)
+	}
+}
+
+func TestGetProjectProfile_RustService(t *testing.T) {
+	testCases := []struct {
+		name               string
+		files              map[string]string
+		expectedVersion    string
+		expectedFramework  string
+		expectedEntrypoint string
+	}{
+		{
+			name: "Axum service with rust-version",
+			files: map[string]string{
+				"Cargo.toml":  "[package]\nname = \"orders\"\nversion = \"0.1.0\"\nrust-version = \"1.78.0\"\n\n[dependencies]\naxum = \"0.7\"\ntokio = { version = \"1\", features = [\"full\"] }\n",
+				"src/main.rs": "fn main() {}\n",
+			},
+			expectedVersion:    "1.78",
+			expectedFramework:  "axum",
+			expectedEntrypoint: "orders",
+		},
+		{
+			name: "Actix service pinned by rust-toolchain.toml",
+			files: map[string]string{
+				"Cargo.toml":          "[package]\nname = \"billing\"\nrust-version = \"1.70\"\n\n[dependencies.actix-web]\nversion = \"4\"\n",
+				"rust-toolchain.toml": "[toolchain]\nchannel = \"1.81.0\"\ncomponents = [\"clippy\"]\n",
+				"src/main.rs":         "fn main() {}\n",
+			},
+			expectedVersion:    "1.81",
+			expectedFramework:  "actix-web",
+			expectedEntrypoint: "billing",
+		},
+		{
+			name: "Stable channel falls back to the default",
+			files: map[string]string{
+				"Cargo.toml":          "[package]\nname = \"tool\"\n\n[[bin]]\nname = \"tool-server\"\npath = \"src/server.rs\"\n",
+				"rust-toolchain.toml": "[toolchain]\nchannel = \"stable\"\n",
+			},
+			expectedVersion:    "1.82",
+			expectedEntrypoint: "tool-server",
+		},
+		{
+			name: "Workspace with a service member",
+			files: map[string]string{
+				"Cargo.toml":             "[workspace]\nmembers = [\"crates/core\", \"crates/api\"]\n\n[workspace.package]\nrust-version = \"1.80\"\n",
+				"crates/core/Cargo.toml": "[package]\nname = \"core\"\n",
+				"crates/api/Cargo.toml":  "[package]\nname = \"api\"\nrust-version.workspace = true\n\n[dependencies]\naxum = \"0.7\"\n",
+				"crates/api/src/main.rs": "fn main() {}\n",
+			},
+			expectedVersion:    "1.80",
+			expectedFramework:  "axum",
+			expectedEntrypoint: "api",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tempDir, err := os.MkdirTemp("", "orchestrator-test-rust-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			for name, content := range tc.files {
+				os.MkdirAll(filepath.Dir(filepath.Join(tempDir, name)), os.ModePerm)
+				os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644)
+			}
+
+			profile, err := GetProjectProfile(tempDir)
+			if err != nil {
+				t.Fatalf("Did not expect an error, but got: %v", err)
+			}
+			if profile.Archetype != ArchetypeRustService {
+				t.Errorf("Expected archetype %s, but got %s", ArchetypeRustService, profile.Archetype)
+			}
+			if profile.LanguageVersion != tc.expectedVersion {
+				t.Errorf("Expected language version %s, but got %s", tc.expectedVersion, profile.LanguageVersion)
+			}
+			if profile.Framework != tc.expectedFramework {
+				t.Errorf("Expected framework %q, but got %q", tc.expectedFramework, profile.Framework)
+			}
+			if profile.Entrypoint != tc.expectedEntrypoint {
+				t.Errorf("Expected entrypoint %s, but got %s", tc.expectedEntrypoint, profile.Entrypoint)
+			}
+		})
+	}
+}
+
+func TestGetProjectProfile_ElixirPhoenix(t *testing.T) {
+	mixExs := "defmodule Shop.MixProject do\n  use Mix.Project\n\n  def project do\n    [\n      app: :shop,\n      version: \"0.1.0\",\n      elixir: \"~> 1.15\",\n      deps: deps()\n    ]\n  end\n\n  defp deps do\n    [\n      {:phoenix, \"~> 1.7.14\"},\n      {:ecto_sql, \"~> 3.10\"},\n      {:postgrex, \">= 0.0.0\"}\n    ]\n  end\nend\n"
+
+	testCases := []struct {
+		name            string
+		files           map[string]string
+		expectedVersion string
+		expectedOTP     string
+		expectedRelease string
+		expectedMigrate string
+	}{
+		{
+			name:            "Versions from .tool-versions",
+			files:           map[string]string{"mix.exs": mixExs, ".tool-versions": "erlang 26.2.5\nelixir 1.16.3-otp-26\n"},
+			expectedVersion: "1.16",
+			expectedOTP:     "26",
+			expectedRelease: "shop",
+			expectedMigrate: `/app/bin/shop eval "Shop.Release.migrate"`,
+		},
+		{
+			name:            "Elixir version from the mix.exs requirement",
+			files:           map[string]string{"mix.exs": mixExs},
+			expectedVersion: "1.15",
+			expectedOTP:     "26",
+			expectedRelease: "shop",
+			expectedMigrate: `/app/bin/shop eval "Shop.Release.migrate"`,
+		},
+		{
+			name:            "Default OTP supported by an older Elixir",
+			files:           map[string]string{"mix.exs": strings.Replace(mixExs, "~> 1.15", "~> 1.14", 1)},
+			expectedVersion: "1.14",
+			expectedOTP:     "25",
+			expectedRelease: "shop",
+			expectedMigrate: `/app/bin/shop eval "Shop.Release.migrate"`,
+		},
+		{
+			name:            "Generated migrate script",
+			files:           map[string]string{"mix.exs": mixExs, ".tool-versions": "elixir 1.17.2-otp-27\n", "rel/overlays/bin/migrate": "#!/bin/sh\n"},
+			expectedVersion: "1.17",
+			expectedOTP:     "27",
+			expectedRelease: "shop",
+			expectedMigrate: "/app/bin/migrate",
+		},
+		{
+			name:            "API without Ecto",
+			files:           map[string]string{"mix.exs": "defmodule Edge.MixProject do\n  def project, do: [app: :edge, deps: [{:phoenix, \"~> 1.7\"}]]\nend\n"},
+			expectedVersion: "1.17",
+			expectedOTP:     "27",
+			expectedRelease: "edge",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tempDir, err := os.MkdirTemp("", "orchestrator-test-phoenix-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			for name, content := range tc.files {
+				os.MkdirAll(filepath.Dir(filepath.Join(tempDir, name)), os.ModePerm)
+				os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644)
+			}
+
+			profile, err := GetProjectProfile(tempDir)
+			if err != nil {
+				t.Fatalf("Did not expect an error, but got: %v", err)
+			}
+			if profile.Archetype != ArchetypeElixirPhoenix {
+				t.Errorf("Expected archetype %s, but got %s", ArchetypeElixirPhoenix, profile.Archetype)
+			}
+			if profile.LanguageVersion != tc.expectedVersion {
+				t.Errorf("Expected language version %s, but got %s", tc.expectedVersion, profile.LanguageVersion)
+			}
+			if profile.RuntimeVersion != tc.expectedOTP {
+				t.Errorf("Expected OTP version %s, but got %s", tc.expectedOTP, profile.RuntimeVersion)
+			}
+			if profile.Entrypoint != tc.expectedRelease {
+				t.Errorf("Expected release %s, but got %s", tc.expectedRelease, profile.Entrypoint)
+			}
+			if profile.MigrationCommand != tc.expectedMigrate {
+				t.Errorf("Expected migration command %q, but got %q", tc.expectedMigrate, profile.MigrationCommand)
+			}
+		})
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	testCases := []struct {
+		name          string
+		files         map[string]string
+		expectedPaths []string
+	}{
+		{
+			name: "Services in subdirectories",
+			files: map[string]string{
+				"services/api/requirements.txt":            "fastapi\n",
+				"services/web/package.json":                `{"dependencies": {"next": "14.0.0"}}`,
+				"services/web/node_modules/x/package.json": `{"dependencies": {"express": "4.0.0"}}`,
+				"tools/go.mod":                             "module example.com/tools\n\ngo 1.22\n",
+				"README.md":                                "# monorepo\n",
+			},
+			expectedPaths: []string{"services/api", "services/web", "tools"},
+		},
+		{
+			name: "Project at the root",
+			files: map[string]string{
+				"requirements.txt":      "fastapi\n",
+				"frontend/package.json": `{"dependencies": {"next": "14.0.0"}}`,
+			},
+			expectedPaths: []string{"."},
+		},
+		{
+			name: "Parent does not claim a nested .NET project",
+			files: map[string]string{
+				"billing/src/Billing.csproj": `<Project Sdk="Microsoft.NET.Sdk.Web"></Project>`,
+				"api/go.mod":                 "module example.com/api\n\ngo 1.22\n",
+			},
+			expectedPaths: []string{"api", "billing/src"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tempDir, err := os.MkdirTemp("", "orchestrator-test-discover-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			for name, content := range tc.files {
+				os.MkdirAll(filepath.Dir(filepath.Join(tempDir, name)), os.ModePerm)
+				os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644)
+			}
+
+			var paths []string
+			for _, match := range Discover(tempDir) {
+				paths = append(paths, match.Profile.Path)
+			}
+			if strings.Join(paths, ",") != strings.Join(tc.expectedPaths, ",") {
+				t.Errorf("Expected services %v, but got %v", tc.expectedPaths, paths)
+			}
+		})
+	}
+}
+
+func TestGetProjectProfile_InferredDatabases(t *testing.T) {
+	testCases := []struct {
+		name              string
+		files             map[string]string
+		expectedDatabases []string
+	}{
+		{
+			name:              "Python driver",
+			files:             map[string]string{"requirements.txt": "fastapi\npsycopg2-binary==2.9.9\n"},
+			expectedDatabases: []string{"postgresql"},
+		},
+		{
+			name: "Prisma datasource before Node drivers",
+			files: map[string]string{
+				"package.json":         `{"dependencies": {"express": "4.18.0", "mongoose": "8.0.0"}}`,
+				"prisma/schema.prisma": "generator client {\n  provider = \"prisma-client-js\"\n}\n\ndatasource db {\n  provider = \"mysql\"\n}\n",
+			},
+			expectedDatabases: []string{"mysql", "mongodb"},
+		},
+		{
+			name: "Spring Data MongoDB",
+			files: map[string]string{
+				"pom.xml": "<project>\n  <dependency>\n    <artifactId>spring-boot-starter-web</artifactId>\n  </dependency>\n  <dependency>\n    <artifactId>spring-boot-starter-data-mongodb</artifactId>\n  </dependency>\n</project>\n",
+			},
+			expectedDatabases: []string{"mongodb"},
+		},
+		{
+			name: "Laravel DB_CONNECTION",
+			files: map[string]string{
+				"artisan":       "#!/usr/bin/env php\n",
+				"composer.json": `{"require": {"php": "^8.2", "laravel/framework": "^11.0"}}`,
+				".env.example":  "APP_NAME=Laravel\nDB_CONNECTION=pgsql\nDB_HOST=127.0.0.1\n",
+			},
+			expectedDatabases: []string{"postgresql"},
+		},
+		{
+			name: "Laravel with SQLite needs no server",
+			files: map[string]string{
+				"artisan":       "#!/usr/bin/env php\n",
+				"composer.json": `{"require": {"php": "^8.2", "laravel/framework": "^11.0"}}`,
+				".env.example":  "DB_CONNECTION=sqlite\n",
+			},
+		},
+		{
+			name:              "Go driver",
+			files:             map[string]string{"go.mod": "module example.com/svc\n\ngo 1.22\n\nrequire github.com/jackc/pgx/v5 v5.5.0\n"},
+			expectedDatabases: []string{"postgresql"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tempDir, err := os.MkdirTemp("", "orchestrator-test-database-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			for name, content := range tc.files {
+				os.MkdirAll(filepath.Dir(filepath.Join(tempDir, name)), os.ModePerm)
+				os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644)
+			}
+
+			profile, err := GetProjectProfile(tempDir)
+			if err != nil {
+				t.Fatalf("Did not expect an error, but got: %v", err)
+			}
+			var databases []string
+			for _, database := range profile.Databases {
+				databases = append(databases, database.Type)
+			}
+			if strings.Join(databases, ",") != strings.Join(tc.expectedDatabases, ",") {
+				t.Errorf("Expected databases %v, but got %v", tc.expectedDatabases, databases)
+			}
+			expectedType := ""
+			if len(tc.expectedDatabases) > 0 {
+				expectedType = tc.expectedDatabases[0]
+			}
+			if profile.DatabaseType != expectedType {
+				t.Errorf("Expected database type %q, but got %q", expectedType, profile.DatabaseType)
+			}
+		})
+	}
+}
+
+func TestGetProjectProfile_InferredBackingServices(t *testing.T) {
+	testCases := []struct {
+		name             string
+		files            map[string]string
+		expectedServices []string
+	}{
+		{
+			name:             "Celery with Redis",
+			files:            map[string]string{"requirements.txt": "fastapi\ncelery[redis]==5.3\nredis\n"},
+			expectedServices: []string{"redis", "rabbitmq"},
+		},
+		{
+			name:             "BullMQ and KafkaJS",
+			files:            map[string]string{"package.json": `{"dependencies": {"express": "4.18.0", "bullmq": "5.0.0", "kafkajs": "2.2.4"}}`},
+			expectedServices: []string{"redis", "kafka"},
+		},
+		{
+			name: "Spring AMQP and Elasticsearch",
+			files: map[string]string{
+				"pom.xml": "<project>\n  <artifactId>spring-boot-starter-web</artifactId>\n  <artifactId>spring-boot-starter-amqp</artifactId>\n  <artifactId>spring-boot-starter-data-elasticsearch</artifactId>\n</project>\n",
+			},
+			expectedServices: []string{"rabbitmq", "elasticsearch"},
+		},
+		{
+			name: "Laravel Redis queue",
+			files: map[string]string{
+				"artisan":       "#!/usr/bin/env php\n",
+				"composer.json": `{"require": {"laravel/framework": "^11.0"}}`,
+				".env.example":  "DB_CONNECTION=mysql\nQUEUE_CONNECTION=redis\n",
+			},
+			expectedServices: []string{"redis"},
+		},
+		{
+			name:  "No services",
+			files: map[string]string{"go.mod": "module example.com/svc\n\ngo 1.22\n"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tempDir, err := os.MkdirTemp("", "orchestrator-test-services-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			for name, content := range tc.files {
+				os.MkdirAll(filepath.Dir(filepath.Join(tempDir, name)), os.ModePerm)
+				os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644)
+			}
+
+			profile, err := GetProjectProfile(tempDir)
+			if err != nil {
+				t.Fatalf("Did not expect an error, but got: %v", err)
+			}
+			var services []string
+			for _, service := range profile.BackingServices {
+				services = append(services, service.Type)
+				if service.Kind != serviceKinds[service.Type] {
+					t.Errorf("Expected %s to be a %s, but got %s", service.Type, serviceKinds[service.Type], service.Kind)
+				}
+			}
+			if strings.Join(services, ",") != strings.Join(tc.expectedServices, ",") {
+				t.Errorf("Expected services %v, but got %v", tc.expectedServices, services)
+			}
+		})
+	}
+}
+
+func TestGetProjectProfile_Endpoint(t *testing.T) {
+	testCases := []struct {
+		name               string
+		files              map[string]string
+		expectedPort       int
+		expectedHealthPath string
+		expectedEntrypoint string
+	}{
+		{
+			name: "FastAPI app in a package",
+			files: map[string]string{
+				"requirements.txt":    "fastapi\nuvicorn\n",
+				"service/__init__.py": "",
+				"service/main.py":     "from fastapi import FastAPI\n\napi: FastAPI = FastAPI()\n\n@api.get(\"/healthz\")\ndef healthz():\n    return {}\n",
+			},
+			expectedPort:       8000,
+			expectedHealthPath: "/healthz",
+			expectedEntrypoint: "service.main:api",
+		},
+		{
+			name:               "FastAPI without an app falls back to main:app",
+			files:              map[string]string{"requirements.txt": "fastapi\n"},
+			expectedPort:       8000,
+			expectedEntrypoint: "main:app",
+		},
+		{
+			name: "Spring Boot server.port placeholder and actuator",
+			files: map[string]string{
+				"pom.xml": "<project>\n  <artifactId>spring-boot-starter-web</artifactId>\n  <artifactId>spring-boot-starter-actuator</artifactId>\n</project>\n",
+				"src/main/resources/application.properties": "spring.application.name=demo\nserver.port=${PORT:8081}\n",
+			},
+			expectedPort:       8081,
+			expectedHealthPath: "/actuator/health",
+		},
+		{
+			name: "Spring Boot application.yml",
+			files: map[string]string{
+				"build.gradle":                       "plugins {\n  id 'org.springframework.boot' version '3.2.0'\n}\n",
+				"src/main/resources/application.yml": "server:\n  port: 9090\n",
+			},
+			expectedPort: 9090,
+		},
+		{
+			name: "Express listening on PORT or 4000",
+			files: map[string]string{
+				"package.json": `{"main": "src/app.js", "dependencies": {"express": "4.18.0"}, "scripts": {"start": "node src/app.js"}}`,
+				"src/app.js":   "const app = require('express')();\napp.get('/health', (req, res) => res.send('ok'));\napp.listen(process.env.PORT || 4000);\n",
+			},
+			expectedPort:       4000,
+			expectedHealthPath: "/health",
+		},
+		{
+			name: "Go service with a literal address",
+			files: map[string]string{
+				"go.mod":             "module example.com/svc\n\ngo 1.22\n",
+				"cmd/server/main.go": "package main\n\nfunc main() {\n\thttp.HandleFunc(\"/livez\", live)\n\thttp.ListenAndServe(\":9000\", nil)\n}\n",
+			},
+			expectedPort:       9000,
+			expectedHealthPath: "/livez",
+		},
+		{
+			name: "Laravel has no HTTP probe",
+			files: map[string]string{
+				"artisan":        "#!/usr/bin/env php\n",
+				"composer.json":  `{"require": {"laravel/framework": "^11.0"}}`,
+				"routes/web.php": "<?php\nRoute::get('/health', fn () => 'ok');\n",
+			},
+			expectedPort: 9000,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tempDir, err := os.MkdirTemp("", "orchestrator-test-endpoint-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			for name, content := range tc.files {
+				os.MkdirAll(filepath.Dir(filepath.Join(tempDir, name)), os.ModePerm)
+				os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644)
+			}
+
+			profile, err := GetProjectProfile(tempDir)
+			if err != nil {
+				t.Fatalf("Did not expect an error, but got: %v", err)
+			}
+			if profile.Port != tc.expectedPort {
+				t.Errorf("Expected port %d, but got %d", tc.expectedPort, profile.Port)
+			}
+			if profile.HealthPath != tc.expectedHealthPath {
+				t.Errorf("Expected health path '%s', but got '%s'", tc.expectedHealthPath, profile.HealthPath)
+			}
+			if tc.expectedEntrypoint != "" && profile.Entrypoint != tc.expectedEntrypoint {
+				t.Errorf("Expected entrypoint '%s', but got '%s'", tc.expectedEntrypoint, profile.Entrypoint)
+			}
+		})
+	}
+}
+
+func TestGetProjectProfile_EnvVars(t *testing.T) {
+	testCases := []struct {
+		name         string
+		files        map[string]string
+		expectedVars []string // NAME=value, with a trailing * for secrets
+	}{
+		{
+			name: "Laravel .env.example and config env() calls",
+			files: map[string]string{
+				"artisan":        "#!/usr/bin/env php\n",
+				"composer.json":  `{"require": {"laravel/framework": "^11.0"}}`,
+				".env.example":   "APP_NAME=Laravel\nAPP_KEY=\n# Mail password\nMAIL_PASSWORD=\"s3cret\"\n",
+				"config/app.php": "<?php\nreturn [\n    'name' => env('APP_NAME', 'Shop'),\n    'timezone' => env('APP_TIMEZONE', 'UTC'),\n    'log' => env('LOG_PATH', storage_path('logs')),\n];\n",
+			},
+			expectedVars: []string{"APP_NAME=Laravel", "APP_KEY=*", "MAIL_PASSWORD=s3cret*", "APP_TIMEZONE=UTC", "LOG_PATH="},
+		},
+		{
+			name: "pydantic settings with a prefix and an alias",
+			files: map[string]string{
+				"requirements.txt": "fastapi\npydantic-settings\n",
+				"app/settings.py":  "class Settings(BaseSettings):\n    model_config = SettingsConfigDict(env_prefix=\"shop_\")\n\n    debug: bool = False\n    stripe_key: SecretStr\n    sentry: str = Field(default=\"\", alias=\"SENTRY_DSN\")\n\n\nsettings = Settings()\nother: int = 1\n",
+			},
+			expectedVars: []string{"SHOP_DEBUG=False", "SHOP_STRIPE_KEY=*", "SENTRY_DSN=*"},
+		},
+		{
+			name: "KEY only marks a secret as the last segment",
+			files: map[string]string{
+				"requirements.txt": "fastapi\n",
+				".env.example":     "CACHE_KEY_PREFIX=shop\nKEY_PREFIX=app\nSORT_KEY=created_at\nSTRIPE_API_KEY=\n",
+			},
+			expectedVars: []string{"CACHE_KEY_PREFIX=shop", "KEY_PREFIX=app", "SORT_KEY=created_at", "STRIPE_API_KEY=*"},
+		},
+		{
+			name: "process.env in a Node.js server",
+			files: map[string]string{
+				"package.json": `{"dependencies": {"express": "4.18.0"}}`,
+				"src/index.js": "const port = process.env.PORT || 3000;\nconst api = process.env.API_URL ?? 'http://localhost:4000';\nconst db = process.env['MONGO_URL'] || 'mongodb://admin:pw@localhost/app';\n",
+			},
+			expectedVars: []string{"API_URL=http://localhost:4000", "MONGO_URL=mongodb://admin:pw@localhost/app*"},
+		},
+		{
+			name: "Spring placeholders",
+			files: map[string]string{
+				"pom.xml": "<project>\n  <artifactId>spring-boot-starter-web</artifactId>\n</project>\n",
+				"src/main/resources/application.properties": "spring.datasource.password=${DB_PASSWORD}\nspring.application.name=${spring.name:demo}\napp.region=${REGION:eu-west-1}\n",
+			},
+			expectedVars: []string{"DB_PASSWORD=*", "REGION=eu-west-1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tempDir, err := os.MkdirTemp("", "orchestrator-test-env-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			for name, content := range tc.files {
+				os.MkdirAll(filepath.Dir(filepath.Join(tempDir, name)), os.ModePerm)
+				os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644)
+			}
+
+			profile, err := GetProjectProfile(tempDir)
+			if err != nil {
+				t.Fatalf("Did not expect an error, but got: %v", err)
+			}
+			var vars []string
+			for _, v := range profile.EnvVars {
+				entry := v.Name + "=" + v.Value
+				if v.Secret {
+					entry += "*"
+				}
+				vars = append(vars, entry)
+			}
+			if strings.Join(vars, ",") != strings.Join(tc.expectedVars, ",") {
+				t.Errorf("Expected variables %v, but got %v", tc.expectedVars, vars)
+			}
+		})
+	}
+}
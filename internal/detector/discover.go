@@ -0,0 +1,61 @@
+package detector
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// maxDiscoverDepth is how many directories below the root Discover searches,
+// enough for layouts such as services/payments/api.
+const maxDiscoverDepth = 3
+
+// discoverSkipDirs are dependency and build output directories that never hold a service.
+var discoverSkipDirs = map[string]bool{
+	"node_modules": true, "vendor": true, "target": true, "dist": true, "build": true,
+	"bin": true, "obj": true, "venv": true, "__pycache__": true, "_build": true, "deps": true,
+}
+
+// Discover finds the services in a repository. When the root itself matches
+// an archetype it is the only service; otherwise every directory that matches
+// is a service and its subdirectories are not searched. Each match's profile
+// has Path set relative to root, and matches are returned in directory order.
+func Discover(root string) []Match {
+	var services []Match
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		rel, _ := filepath.Rel(root, path)
+		rel = filepath.ToSlash(rel)
+		if rel != "." {
+			if discoverSkipDirs[d.Name()] || strings.HasPrefix(d.Name(), ".") || strings.Count(rel, "/") >= maxDiscoverDepth {
+				return filepath.SkipDir
+			}
+		}
+
+		match, ok := ownMatch(path)
+		if !ok {
+			return nil
+		}
+		match.Profile.Path = rel
+		services = append(services, match)
+		// Skipping the root ends the walk, which is what a single-service repository wants.
+		return filepath.SkipDir
+	})
+	return services
+}
+
+// ownMatch returns the best match for dir backed by a file in dir itself.
+// Some detectors look a level or two down (e.g. for .csproj files), and a
+// parent directory must not claim a service that lives below it.
+func ownMatch(dir string) (Match, bool) {
+	for _, match := range DetectAll(dir) {
+		for _, e := range match.Evidence {
+			if e.File != "" && !strings.Contains(filepath.ToSlash(e.File), "/") {
+				return match, true
+			}
+		}
+	}
+	return Match{}, false
+}
@@ -0,0 +1,94 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// djangoSettingsPattern matches the default settings module set in manage.py.
+var djangoSettingsPattern = regexp.MustCompile(`DJANGO_SETTINGS_MODULE['"]\s*,\s*['"]([\w.]+)['"]`)
+
+// asgiServerPackages are dependencies that mean a Django project is served over ASGI.
+var asgiServerPackages = []string{"channels", "daphne", "uvicorn"}
+
+// djangoDetector recognises Django projects from manage.py, the settings
+// module and the django dependency.
+type djangoDetector struct{}
+
+func (djangoDetector) Name() string { return string(ArchetypePythonDjango) }
+
+func (djangoDetector) Detect(dirPath string) (*ProjectProfile, float64, []Evidence) {
+	project := parsePythonProject(dirPath)
+	if project == nil {
+		return nil, 0, nil
+	}
+	dependency, ok := project.Has("django")
+	if !ok {
+		return nil, 0, nil
+	}
+
+	evidence := []Evidence{dependency, project.ManagerEvidence}
+	profile := &ProjectProfile{Archetype: ArchetypePythonDjango, Framework: "django"}
+	confidence := 0.7
+
+	// manage.py names the settings module, whose package holds wsgi.py and asgi.py.
+	managePath := filepath.Join(dirPath, "manage.py")
+	projectPackage := ""
+	if line, text := findLine(managePath, djangoSettingsPattern.MatchString); line > 0 {
+		settings := djangoSettingsPattern.FindStringSubmatch(text)[1]
+		projectPackage = strings.SplitN(settings, ".", 2)[0]
+		evidence = append(evidence, Evidence{File: "manage.py", Line: line, Detail: text})
+		confidence = 0.95
+	} else if fileExists(managePath) {
+		evidence = append(evidence, Evidence{File: "manage.py", Detail: "manage.py present"})
+		confidence = 0.9
+	}
+	if projectPackage == "" {
+		projectPackage = findDjangoSettingsPackage(dirPath)
+	}
+	if projectPackage != "" && fileExists(filepath.Join(dirPath, projectPackage, "settings.py")) {
+		evidence = append(evidence, Evidence{File: filepath.ToSlash(filepath.Join(projectPackage, "settings.py")), Detail: "Django settings module"})
+	}
+
+	if projectPackage == "" {
+		profile.Entrypoint = "config.wsgi:application"
+		profile.setSource("entrypoint", "default (no Django settings module found)")
+	} else {
+		profile.Entrypoint = projectPackage + ".wsgi:application"
+		profile.setSource("entrypoint", projectPackage+"/wsgi.py")
+		if fileExists(filepath.Join(dirPath, projectPackage, "asgi.py")) {
+			for _, name := range asgiServerPackages {
+				if _, ok := project.Has(name); ok {
+					profile.Entrypoint = projectPackage + ".asgi:application"
+					profile.setSource("entrypoint", projectPackage+"/asgi.py (depends on "+name+")")
+					break
+				}
+			}
+		}
+	}
+
+	setPythonVersion(profile, dirPath)
+	setPackageManager(profile, project)
+	return profile, confidence, evidence
+}
+
+// findDjangoSettingsPackage returns the top-level package containing both
+// settings.py and wsgi.py, for projects whose manage.py could not be read.
+func findDjangoSettingsPackage(dirPath string) string {
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return ""
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		pkg := filepath.Join(dirPath, entry.Name())
+		if fileExists(filepath.Join(pkg, "settings.py")) && fileExists(filepath.Join(pkg, "wsgi.py")) {
+			return entry.Name()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,194 @@
+package detector
+
+import (
+	"encoding/xml"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// csproj is the subset of an SDK-style project file the detector reads.
+type csproj struct {
+	Sdk            string `xml:"Sdk,attr"`
+	PropertyGroups []struct {
+		TargetFramework  string `xml:"TargetFramework"`
+		TargetFrameworks string `xml:"TargetFrameworks"`
+		AssemblyName     string `xml:"AssemblyName"`
+	} `xml:"PropertyGroup"`
+	ItemGroups []struct {
+		PackageReferences []struct {
+			Include string `xml:"Include,attr"`
+		} `xml:"PackageReference"`
+		FrameworkReferences []struct {
+			Include string `xml:"Include,attr"`
+		} `xml:"FrameworkReference"`
+	} `xml:"ItemGroup"`
+}
+
+// aspNetReference returns the ASP.NET Core package or shared framework the
+// project references, or "" when it references neither.
+func (p *csproj) aspNetReference() string {
+	for _, group := range p.ItemGroups {
+		for _, ref := range group.FrameworkReferences {
+			if ref.Include == "Microsoft.AspNetCore.App" {
+				return ref.Include
+			}
+		}
+		for _, ref := range group.PackageReferences {
+			if strings.HasPrefix(ref.Include, "Microsoft.AspNetCore.") {
+				return ref.Include
+			}
+		}
+	}
+	return ""
+}
+
+// dotnetSkipDirs are directories never searched for project files.
+var dotnetSkipDirs = map[string]bool{"bin": true, "obj": true, "node_modules": true, ".git": true}
+
+// aspNetDetector recognises ASP.NET Core projects from a .csproj using the Web
+// SDK or referencing ASP.NET Core.
+type aspNetDetector struct{}
+
+func (aspNetDetector) Name() string { return string(ArchetypeDotnetAspNet) }
+
+func (aspNetDetector) Detect(dirPath string) (*ProjectProfile, float64, []Evidence) {
+	projects, solutions := findDotnetProjects(dirPath)
+	if len(projects) == 0 {
+		return nil, 0, nil
+	}
+
+	var evidence []Evidence
+	var confidence float64
+	profile := &ProjectProfile{Archetype: ArchetypeDotnetAspNet, Framework: "aspnetcore"}
+
+	// Prefer a Web SDK project, which is the one that runs, then one referencing
+	// ASP.NET Core. Class libraries and console apps are not web services.
+	var entry string
+	var project *csproj
+	for _, candidate := range projects {
+		path := filepath.Join(dirPath, candidate)
+		parsed, err := parseCsproj(path)
+		if err != nil {
+			continue
+		}
+		if parsed.Sdk == "Microsoft.NET.Sdk.Web" {
+			line, text := findLine(path, func(l string) bool { return strings.Contains(l, "Microsoft.NET.Sdk.Web") })
+			entry, project, confidence = candidate, parsed, 0.9
+			evidence = []Evidence{{File: candidate, Line: line, Detail: text}}
+			break
+		}
+		if reference := parsed.aspNetReference(); reference != "" && project == nil {
+			line, text := findLine(path, func(l string) bool { return strings.Contains(l, `"`+reference+`"`) })
+			entry, project, confidence = candidate, parsed, 0.75
+			evidence = []Evidence{{File: candidate, Line: line, Detail: text}}
+		}
+	}
+	if project == nil {
+		return nil, 0, nil
+	}
+	for _, sln := range solutions {
+		evidence = append(evidence, Evidence{File: sln, Detail: sln + " present"})
+	}
+
+	profile.Entrypoint = entry
+	profile.setSource("entrypoint", entry)
+	profile.AssemblyName = strings.TrimSuffix(filepath.Base(entry), ".csproj")
+	for _, group := range project.PropertyGroups {
+		if name := strings.TrimSpace(group.AssemblyName); name != "" {
+			profile.AssemblyName = name
+			profile.setSource("assembly_name", entry+" AssemblyName")
+		}
+	}
+
+	for _, group := range project.PropertyGroups {
+		frameworks := strings.TrimSpace(group.TargetFramework)
+		if frameworks == "" {
+			frameworks = strings.TrimSpace(group.TargetFrameworks)
+		}
+		if version := dotnetVersion(frameworks); version != "" {
+			profile.LanguageVersion = version
+			profile.setSource("language_version", entry+" TargetFramework ("+frameworks+")")
+			break
+		}
+	}
+	if profile.LanguageVersion == "" {
+		profile.LanguageVersion = "8.0" // Default to .NET 8
+		profile.setSource("language_version", "default (no TargetFramework declared)")
+	}
+	return profile, confidence, evidence
+}
+
+// findDotnetProjects returns the .csproj and .sln files in dirPath and up to
+// two directories below it (e.g. src/Api/Api.csproj), as slash-separated
+// relative paths, shallowest first.
+func findDotnetProjects(dirPath string) ([]string, []string) {
+	var projects, solutions []string
+	_ = filepath.WalkDir(dirPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(dirPath, path)
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if rel != "." && (dotnetSkipDirs[d.Name()] || strings.Count(rel, "/") >= 2) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch filepath.Ext(path) {
+		case ".csproj":
+			projects = append(projects, rel)
+		case ".sln", ".slnx":
+			solutions = append(solutions, rel)
+		}
+		return nil
+	})
+
+	byDepth := func(paths []string) {
+		sort.SliceStable(paths, func(i, j int) bool {
+			return strings.Count(paths[i], "/") < strings.Count(paths[j], "/")
+		})
+	}
+	byDepth(projects)
+	byDepth(solutions)
+	return projects, solutions
+}
+
+func parseCsproj(path string) (*csproj, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var project csproj
+	if err := xml.Unmarshal(data, &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// dotnetVersion turns a target framework moniker such as "net8.0" or
+// "netcoreapp3.1" into a runtime version. When several are listed, the
+// newest one wins.
+func dotnetVersion(frameworks string) string {
+	best := ""
+	for _, moniker := range strings.Split(frameworks, ";") {
+		moniker = strings.TrimSpace(moniker)
+		// Platform-specific monikers such as "net8.0-windows" still name the runtime first.
+		moniker, _, _ = strings.Cut(moniker, "-")
+		version := strings.TrimPrefix(moniker, "netcoreapp")
+		if version == moniker {
+			version = strings.TrimPrefix(moniker, "net")
+		}
+		// .NET Framework monikers such as "net48" have no dot and no Linux runtime image.
+		if version == moniker || !strings.Contains(version, ".") {
+			continue
+		}
+		if best == "" || compareVersions(version, best) > 0 {
+			best = version
+		}
+	}
+	return best
+}
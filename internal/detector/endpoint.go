@@ -0,0 +1,235 @@
+package detector
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultPorts is the port each archetype's generated image listens on when
+// the project does not choose one. For most archetypes the generated
+// Dockerfile passes the port to the server, so only the default matters.
+var defaultPorts = map[Archetype]int{
+	ArchetypeJavaSpringBoot: 8080,
+	ArchetypePythonFastAPI:  8000,
+	ArchetypePHPLaravel:     9000, // php-fpm, speaking FastCGI rather than HTTP
+	ArchetypeNodeJSNextJS:   3000,
+	ArchetypeGoService:      8080,
+	ArchetypeNodeJSServer:   3000,
+	ArchetypeNodeJSSSR:      3000,
+	ArchetypeNodeJSSPA:      8080, // nginx-unprivileged
+	ArchetypePythonDjango:   8000,
+	ArchetypePythonFlask:    8000,
+	ArchetypeRubyRails:      3000,
+	ArchetypeDotnetAspNet:   8080,
+	ArchetypeRustService:    8080,
+	ArchetypeElixirPhoenix:  4000,
+}
+
+// DefaultPort returns the port an archetype listens on unless the project chooses another.
+func DefaultPort(archetype Archetype) int {
+	if port, ok := defaultPorts[archetype]; ok {
+		return port
+	}
+	return 8000
+}
+
+// ServesHTTP reports whether an archetype's container answers HTTP on its
+// port. Laravel's php-fpm speaks FastCGI, so neither a probe nor an Ingress
+// can reach it without a web server in front.
+func ServesHTTP(archetype Archetype) bool {
+	return archetype != ArchetypePHPLaravel
+}
+
+var (
+	// springPortPattern matches server.port in application.properties.
+	springPortPattern = regexp.MustCompile(`^\s*server\.port\s*[=:]\s*(\S+)`)
+	// placeholderDefaultPattern matches the default of a placeholder such as ${PORT:8081}.
+	placeholderDefaultPattern = regexp.MustCompile(`^\$\{\w+:(\d+)\}$`)
+	// nodePortPatterns match `process.env.PORT || 4000` and `app.listen(4000)`.
+	nodePortPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`process\.env\.PORT\s*(?:\|\||\?\?)\s*['"]?(\d{2,5})`),
+		regexp.MustCompile(`\.listen\(\s*(\d{2,5})\b`),
+	}
+	// goPortPattern matches an address literal passed to a listener, e.g. http.ListenAndServe(":8081", nil) or Addr: ":8081".
+	goPortPattern = regexp.MustCompile(`(?:ListenAndServe|Listen|Run|Start|Addr)\b[^"\n]*"[\w.]*:(\d{2,5})"`)
+	// rustPortPatterns match "0.0.0.0:3000", ("0.0.0.0", 3000) and ([0, 0, 0, 0], 3000).
+	rustPortPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`"(?:0\.0\.0\.0|127\.0\.0\.1|\[::\]|localhost):(\d{2,5})"`),
+		regexp.MustCompile(`\(\s*"(?:0\.0\.0\.0|127\.0\.0\.1|::|localhost)"\s*,\s*(\d{2,5})\s*\)`),
+		regexp.MustCompile(`\(\s*\[\s*0\s*,\s*0\s*,\s*0\s*,\s*0\s*\]\s*,\s*(\d{2,5})\s*\)`),
+	}
+	// healthPathPattern matches a health check route literal in source code.
+	healthPathPattern = regexp.MustCompile("[\"'`](/(?:api/)?(?:healthz|health|livez|readyz|_health))/?[\"'`]")
+)
+
+// nodeEntryFiles are the files checked for the port of a Node.js server, after package.json main.
+var nodeEntryFiles = []string{
+	"server.js", "index.js", "app.js", "main.js",
+	"src/main.ts", "src/index.ts", "src/server.ts", "src/app.ts",
+	"src/main.js", "src/index.js", "src/server.js", "src/app.js",
+}
+
+// healthSourceExtensions are the files searched for health check routes.
+var healthSourceExtensions = map[string]bool{
+	".py": true, ".js": true, ".mjs": true, ".ts": true, ".go": true, ".rb": true, ".php": true,
+	".java": true, ".kt": true, ".cs": true, ".rs": true, ".ex": true, ".exs": true,
+}
+
+// setEndpoint records the port the app listens on and the path its health
+// check is served at, if one can be found.
+func setEndpoint(profile *ProjectProfile, dirPath string) {
+	profile.Port = DefaultPort(profile.Archetype)
+	profile.setSource("port", "default for "+string(profile.Archetype))
+	if port, source := detectPort(profile, dirPath); port > 0 {
+		profile.Port = port
+		profile.setSource("port", source)
+	}
+
+	// php-fpm cannot answer an HTTP probe, whatever routes the app has.
+	if !ServesHTTP(profile.Archetype) {
+		return
+	}
+	if path, source := healthPath(profile, dirPath); path != "" {
+		profile.HealthPath = path
+		profile.setSource("health_path", source)
+	}
+}
+
+// detectPort finds a port the project sets itself. Archetypes whose server
+// is started by the generated Dockerfile take the port from there instead.
+func detectPort(profile *ProjectProfile, dirPath string) (int, string) {
+	switch profile.Archetype {
+	case ArchetypeJavaSpringBoot:
+		return springPort(dirPath)
+	case ArchetypeNodeJSServer:
+		files := nodeEntryFiles
+		if pkg, err := readPackageJSON(dirPath); err == nil && pkg.Main != "" {
+			files = append([]string{pkg.Main}, files...)
+		}
+		return firstPort(dirPath, files, nodePortPatterns)
+	case ArchetypeGoService:
+		files, _ := filepath.Glob(filepath.Join(dirPath, profile.Entrypoint, "*.go"))
+		for i, file := range files {
+			files[i], _ = filepath.Rel(dirPath, file)
+		}
+		return firstPort(dirPath, files, []*regexp.Regexp{goPortPattern})
+	case ArchetypeRustService:
+		return firstPort(dirPath, []string{"src/main.rs", "src/bin/" + profile.Entrypoint + ".rs"}, rustPortPatterns)
+	}
+	return 0, ""
+}
+
+// springPort reads server.port from application.properties or application.yml.
+func springPort(dirPath string) (int, string) {
+	resources := filepath.Join(dirPath, "src", "main", "resources")
+	if _, text := findLine(filepath.Join(resources, "application.properties"), springPortPattern.MatchString); text != "" {
+		if port := parsePort(springPortPattern.FindStringSubmatch(text)[1]); port > 0 {
+			return port, "application.properties server.port"
+		}
+	}
+	for _, name := range []string{"application.yml", "application.yaml"} {
+		content, err := os.ReadFile(filepath.Join(resources, name))
+		if err != nil {
+			continue
+		}
+		var config struct {
+			Server struct {
+				Port string `yaml:"port"`
+			} `yaml:"server"`
+		}
+		if yaml.Unmarshal(content, &config) == nil {
+			if port := parsePort(config.Server.Port); port > 0 {
+				return port, name + " server.port"
+			}
+		}
+	}
+	return 0, ""
+}
+
+// parsePort reads a port number, or the default of a ${VAR:port} placeholder.
+func parsePort(value string) int {
+	value = strings.TrimSpace(value)
+	if m := placeholderDefaultPattern.FindStringSubmatch(value); m != nil {
+		value = m[1]
+	}
+	port, err := strconv.Atoi(value)
+	if err != nil || port <= 0 || port > 65535 {
+		return 0
+	}
+	return port
+}
+
+// firstPort returns the first port matched by patterns in files, in order.
+func firstPort(dirPath string, files []string, patterns []*regexp.Regexp) (int, string) {
+	for _, file := range files {
+		for _, pattern := range patterns {
+			if line, text := findLine(filepath.Join(dirPath, file), pattern.MatchString); line > 0 {
+				if port := parsePort(pattern.FindStringSubmatch(text)[1]); port > 0 {
+					return port, filepath.ToSlash(file) + ":" + strconv.Itoa(line)
+				}
+			}
+		}
+	}
+	return 0, ""
+}
+
+// healthPath finds the route the app serves its health check on: a
+// framework's built-in endpoint first, then a health route in the source.
+func healthPath(profile *ProjectProfile, dirPath string) (string, string) {
+	switch profile.Archetype {
+	case ArchetypeJavaSpringBoot:
+		for _, buildFile := range []string{"pom.xml", "build.gradle", "build.gradle.kts"} {
+			if line, _ := findLine(filepath.Join(dirPath, buildFile), func(l string) bool { return strings.Contains(l, "spring-boot-starter-actuator") }); line > 0 {
+				return "/actuator/health", buildFile + " spring-boot-starter-actuator"
+			}
+		}
+	case ArchetypeRubyRails:
+		// Rails 7.1+ generates `get "up" => "rails/health#show"`.
+		if line, _ := findLine(filepath.Join(dirPath, "config", "routes.rb"), func(l string) bool { return strings.Contains(l, "rails/health#show") }); line > 0 {
+			return "/up", "config/routes.rb:" + strconv.Itoa(line)
+		}
+	}
+
+	var path, source string
+	walkSource(dirPath, healthSourceExtensions, func(rel, p string) bool {
+		if line, text := findLine(p, healthPathPattern.MatchString); line > 0 {
+			path = healthPathPattern.FindStringSubmatch(text)[1]
+			source = rel + ":" + strconv.Itoa(line)
+			return false
+		}
+		return true
+	})
+	return path, source
+}
+
+// walkSource calls fn with the slash-separated relative path and the full
+// path of each file below dirPath whose extension is in extensions, skipping
+// hidden and dependency directories and anything more than four levels down.
+// The walk stops when fn returns false.
+func walkSource(dirPath string, extensions map[string]bool, fn func(rel, path string) bool) {
+	_ = filepath.WalkDir(dirPath, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(dirPath, p)
+		if d.IsDir() {
+			if rel != "." && (discoverSkipDirs[d.Name()] || strings.HasPrefix(d.Name(), ".") || strings.Count(filepath.ToSlash(rel), "/") >= 4) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !extensions[filepath.Ext(p)] {
+			return nil
+		}
+		if !fn(filepath.ToSlash(rel), p) {
+			return fs.SkipAll
+		}
+		return nil
+	})
+}
@@ -0,0 +1,79 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	// flaskAppPattern matches a module-level application such as `app = Flask(__name__)`.
+	flaskAppPattern = regexp.MustCompile(`^(\w+)\s*=\s*Flask\(`)
+	// flaskFactoryPattern matches an application factory.
+	flaskFactoryPattern = regexp.MustCompile(`^def (create_app|make_app)\(`)
+)
+
+// flaskModules are the files checked for the Flask application, in order.
+var flaskModules = []string{"wsgi.py", "app.py", "application.py", "main.py", "run.py", "server.py"}
+
+// flaskDetector recognises Flask projects from their declared dependencies.
+type flaskDetector struct{}
+
+func (flaskDetector) Name() string { return string(ArchetypePythonFlask) }
+
+func (flaskDetector) Detect(dirPath string) (*ProjectProfile, float64, []Evidence) {
+	project := parsePythonProject(dirPath)
+	if project == nil {
+		return nil, 0, nil
+	}
+	dependency, ok := project.Has("flask")
+	if !ok {
+		return nil, 0, nil
+	}
+
+	evidence := []Evidence{dependency, project.ManagerEvidence}
+	profile := &ProjectProfile{Archetype: ArchetypePythonFlask, Framework: "flask"}
+	confidence := 0.85
+
+	if entrypoint, e := flaskEntrypoint(dirPath); entrypoint != "" {
+		profile.Entrypoint = entrypoint
+		profile.setSource("entrypoint", e.File)
+		evidence = append(evidence, e)
+	} else {
+		profile.Entrypoint = "app:app"
+		profile.setSource("entrypoint", "default (no Flask application found)")
+		confidence -= 0.1
+	}
+
+	setPythonVersion(profile, dirPath)
+	setPackageManager(profile, project)
+	return profile, confidence, evidence
+}
+
+// flaskEntrypoint finds the Flask application and returns it in gunicorn's
+// "module:variable" form, or "module:create_app()" for an application factory.
+// Top-level modules are checked first, then the __init__.py of each package.
+func flaskEntrypoint(dirPath string) (string, Evidence) {
+	candidates := append([]string{}, flaskModules...)
+	if entries, err := os.ReadDir(dirPath); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+				candidates = append(candidates, filepath.Join(entry.Name(), "__init__.py"))
+			}
+		}
+	}
+
+	for _, candidate := range candidates {
+		path := filepath.Join(dirPath, candidate)
+		module := strings.TrimSuffix(strings.TrimSuffix(filepath.ToSlash(candidate), "/__init__.py"), ".py")
+		file := filepath.ToSlash(candidate)
+		if line, text := findLine(path, flaskAppPattern.MatchString); line > 0 {
+			return module + ":" + flaskAppPattern.FindStringSubmatch(text)[1], Evidence{File: file, Line: line, Detail: text}
+		}
+		if line, text := findLine(path, flaskFactoryPattern.MatchString); line > 0 {
+			return module + ":" + flaskFactoryPattern.FindStringSubmatch(text)[1] + "()", Evidence{File: file, Line: line, Detail: text}
+		}
+	}
+	return "", Evidence{}
+}
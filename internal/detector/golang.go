@@ -0,0 +1,173 @@
+package detector
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// goFrameworks maps module paths of supported web frameworks to their names.
+// Projects using none of them are served with the standard library's net/http.
+var goFrameworks = []struct {
+	Module string
+	Name   string
+}{
+	{Module: "github.com/gin-gonic/gin", Name: "gin"},
+	{Module: "github.com/labstack/echo", Name: "echo"},
+	{Module: "github.com/gofiber/fiber", Name: "fiber"},
+}
+
+// goDetector recognises Go services from go.mod.
+type goDetector struct{}
+
+func (goDetector) Name() string { return string(ArchetypeGoService) }
+
+func (goDetector) Detect(dirPath string) (*ProjectProfile, float64, []Evidence) {
+	goModPath := filepath.Join(dirPath, "go.mod")
+	mod, err := parseGoMod(goModPath)
+	if err != nil {
+		return nil, 0, nil
+	}
+
+	profile := &ProjectProfile{Archetype: ArchetypeGoService, Framework: "net/http"}
+	evidence := []Evidence{{File: "go.mod", Line: mod.ModuleLine, Detail: "module " + mod.Module}}
+	confidence := 0.7
+
+	if mod.GoVersion != "" {
+		profile.LanguageVersion = majorMinor(mod.GoVersion)
+		profile.setSource("language_version", fmt.Sprintf("go.mod go directive (%s)", mod.GoVersion))
+	} else {
+		profile.LanguageVersion = "1.22"
+		profile.setSource("language_version", "default (no go directive in go.mod)")
+	}
+
+	profile.setSource("framework", "default (no web framework in go.mod)")
+	for _, fw := range goFrameworks {
+		if line, ok := mod.Requires[fw.Module]; ok {
+			profile.Framework = fw.Name
+			profile.setSource("framework", "go.mod require "+fw.Module)
+			evidence = append(evidence, Evidence{File: "go.mod", Line: line, Detail: "require " + fw.Module})
+			confidence = 0.9
+			break
+		}
+	}
+
+	if entrypoint, file := goMainPackage(dirPath); entrypoint != "" {
+		profile.Entrypoint = entrypoint
+		profile.setSource("entrypoint", file)
+		evidence = append(evidence, Evidence{File: file, Detail: file + " declares package main"})
+	} else {
+		// A module without a main package is most likely a library.
+		profile.Entrypoint = "."
+		profile.setSource("entrypoint", "default (no main package found)")
+		confidence -= 0.2
+	}
+	return profile, confidence, evidence
+}
+
+// goMod is the subset of go.mod the detector cares about.
+type goMod struct {
+	Module     string
+	ModuleLine int
+	GoVersion  string
+	// Requires maps each required module path, without any major version
+	// suffix such as /v4, to the line it is declared on.
+	Requires map[string]int
+}
+
+func parseGoMod(goModPath string) (*goMod, error) {
+	f, err := os.Open(goModPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	mod := &goMod{Requires: map[string]int{}}
+	inRequire := false
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch {
+		case inRequire && fields[0] == ")":
+			inRequire = false
+		case inRequire:
+			mod.Requires[trimMajorSuffix(fields[0])] = lineNo
+		case fields[0] == "module" && len(fields) > 1:
+			mod.Module = strings.Trim(fields[1], `"`)
+			mod.ModuleLine = lineNo
+		case fields[0] == "go" && len(fields) > 1:
+			mod.GoVersion = fields[1]
+		case fields[0] == "require" && len(fields) > 1 && fields[1] == "(":
+			inRequire = true
+		case fields[0] == "require" && len(fields) > 1:
+			mod.Requires[trimMajorSuffix(fields[1])] = lineNo
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if mod.Module == "" {
+		return nil, fmt.Errorf("no module directive in %s", goModPath)
+	}
+	return mod, nil
+}
+
+// trimMajorSuffix strips a /vN major version suffix from a module path.
+func trimMajorSuffix(module string) string {
+	i := strings.LastIndex(module, "/v")
+	if i < 0 {
+		return module
+	}
+	for _, r := range module[i+2:] {
+		if r < '0' || r > '9' {
+			return module
+		}
+	}
+	return module[:i]
+}
+
+// goMainPackage finds the package to build: the module root if it holds
+// package main, otherwise the first cmd/<name> directory that does. It returns
+// the package path (e.g. "./cmd/server") and the file that proved it.
+func goMainPackage(dirPath string) (string, string) {
+	if file := mainFileIn(dirPath); file != "" {
+		return ".", file
+	}
+	entries, err := os.ReadDir(filepath.Join(dirPath, "cmd"))
+	if err != nil {
+		return "", ""
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if file := mainFileIn(filepath.Join(dirPath, "cmd", entry.Name())); file != "" {
+			return "./cmd/" + entry.Name(), filepath.ToSlash(filepath.Join("cmd", entry.Name(), file))
+		}
+	}
+	return "", ""
+}
+
+// mainFileIn returns the name of a non-test .go file in dir declaring package main.
+func mainFileIn(dir string) string {
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	for _, path := range matches {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		if line, _ := findLine(path, func(l string) bool { return strings.TrimSpace(l) == "package main" }); line > 0 {
+			return filepath.Base(path)
+		}
+	}
+	return ""
+}
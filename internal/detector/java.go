@@ -0,0 +1,187 @@
+package detector
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Java build tools recorded in ProjectProfile.BuildTool.
+const (
+	BuildToolMaven  = "maven"
+	BuildToolGradle = "gradle"
+)
+
+// springBootDetector recognises Java projects built with Maven or Gradle.
+// A Spring Boot dependency or plugin raises the confidence.
+type springBootDetector struct{}
+
+func (springBootDetector) Name() string { return string(ArchetypeJavaSpringBoot) }
+
+func (springBootDetector) Detect(dirPath string) (*ProjectProfile, float64, []Evidence) {
+	profile := &ProjectProfile{Archetype: ArchetypeJavaSpringBoot}
+	var evidence []Evidence
+	confidence := 0.0
+	for _, buildFile := range []string{"pom.xml", "build.gradle", "build.gradle.kts"} {
+		path := filepath.Join(dirPath, buildFile)
+		if !fileExists(path) {
+			continue
+		}
+		evidence = append(evidence, Evidence{File: buildFile, Detail: buildFile + " present"})
+		confidence = max(confidence, 0.6)
+
+		if line, text := findLine(path, func(l string) bool { return strings.Contains(l, "spring-boot") }); line > 0 {
+			evidence = append(evidence, Evidence{File: buildFile, Line: line, Detail: text})
+			confidence = 0.9
+		}
+
+		if profile.BuildTool != "" {
+			continue
+		}
+		if buildFile == "pom.xml" {
+			profile.BuildTool = BuildToolMaven
+			profile.BuildToolWrapper = fileExists(filepath.Join(dirPath, "mvnw"))
+		} else {
+			profile.BuildTool = BuildToolGradle
+			profile.BuildToolWrapper = fileExists(filepath.Join(dirPath, "gradlew"))
+		}
+		profile.setSource("build_tool", buildFile)
+
+		if version, source := javaVersion(path, buildFile); version != "" {
+			profile.LanguageVersion = version
+			profile.setSource("language_version", source)
+		}
+	}
+	if len(evidence) == 0 {
+		return nil, 0, nil
+	}
+
+	if profile.BuildToolWrapper {
+		wrapper := map[string]string{BuildToolMaven: "mvnw", BuildToolGradle: "gradlew"}[profile.BuildTool]
+		evidence = append(evidence, Evidence{File: wrapper, Detail: wrapper + " wrapper present"})
+	}
+	if profile.LanguageVersion == "" {
+		profile.LanguageVersion = "17" // Default to Java 17
+		profile.setSource("language_version", "default (no Java version declared in the build file)")
+	}
+	return profile, confidence, evidence
+}
+
+// javaVersion reads the Java version from a Maven or Gradle build file and
+// describes where it was found.
+func javaVersion(path, buildFile string) (string, string) {
+	if buildFile == "pom.xml" {
+		version, source, err := parseJavaVersionFromPom(path)
+		if err != nil {
+			return "", ""
+		}
+		return version, source
+	}
+	return parseJavaVersionFromGradle(path, buildFile)
+}
+
+// pom.xml structure for the Java version
+type Pom struct {
+	Properties struct {
+		Entries []struct {
+			XMLName xml.Name
+			Value   string `xml:",chardata"`
+		} `xml:",any"`
+	} `xml:"properties"`
+	Plugins []struct {
+		ArtifactID    string `xml:"artifactId"`
+		Configuration struct {
+			Release string `xml:"release"`
+			Source  string `xml:"source"`
+		} `xml:"configuration"`
+	} `xml:"build>plugins>plugin"`
+}
+
+// pomVersionProperties are checked in order; the first one set wins.
+var pomVersionProperties = []string{"maven.compiler.release", "java.version", "maven.compiler.source"}
+
+func parseJavaVersionFromPom(pomPath string) (string, string, error) {
+	data, err := os.ReadFile(pomPath)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read pom.xml: %w", err)
+	}
+
+	var pom Pom
+	if err := xml.Unmarshal(data, &pom); err != nil {
+		return "", "", fmt.Errorf("failed to parse pom.xml: %w", err)
+	}
+
+	properties := map[string]string{}
+	for _, entry := range pom.Properties.Entries {
+		properties[entry.XMLName.Local] = strings.TrimSpace(entry.Value)
+	}
+
+	for _, name := range pomVersionProperties {
+		if version := normalizeJavaVersion(resolvePomProperty(properties[name], properties)); version != "" {
+			return version, "pom.xml property " + name, nil
+		}
+	}
+
+	for _, plugin := range pom.Plugins {
+		if plugin.ArtifactID != "maven-compiler-plugin" {
+			continue
+		}
+		if version := normalizeJavaVersion(resolvePomProperty(plugin.Configuration.Release, properties)); version != "" {
+			return version, "pom.xml maven-compiler-plugin <release>", nil
+		}
+		if version := normalizeJavaVersion(resolvePomProperty(plugin.Configuration.Source, properties)); version != "" {
+			return version, "pom.xml maven-compiler-plugin <source>", nil
+		}
+	}
+	return "", "", fmt.Errorf("no Java version declared in pom.xml")
+}
+
+// resolvePomProperty expands a value of the form ${name} from properties.
+func resolvePomProperty(value string, properties map[string]string) string {
+	for i := 0; i < 5 && strings.HasPrefix(value, "${") && strings.HasSuffix(value, "}"); i++ {
+		value = properties[strings.TrimSuffix(strings.TrimPrefix(value, "${"), "}")]
+	}
+	return value
+}
+
+// gradleVersionPatterns match the usual ways a Gradle build declares its Java version,
+// in priority order: toolchains first, then source compatibility.
+var gradleVersionPatterns = []struct {
+	Pattern *regexp.Regexp
+	Source  string
+}{
+	{regexp.MustCompile(`JavaLanguageVersion\.of\(\s*["']?(\d+)["']?\s*\)`), "toolchain languageVersion"},
+	{regexp.MustCompile(`jvmToolchain\(\s*(\d+)\s*\)`), "kotlin jvmToolchain"},
+	{regexp.MustCompile(`sourceCompatibility\s*=\s*JavaVersion\.VERSION_(\d+(?:_\d+)?)`), "sourceCompatibility"},
+	{regexp.MustCompile(`sourceCompatibility\s*=\s*['"]?(\d+(?:\.\d+)?)['"]?`), "sourceCompatibility"},
+}
+
+func parseJavaVersionFromGradle(path, buildFile string) (string, string) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", ""
+	}
+	for _, p := range gradleVersionPatterns {
+		if m := p.Pattern.FindStringSubmatch(string(content)); m != nil {
+			if version := normalizeJavaVersion(strings.ReplaceAll(m[1], "_", ".")); version != "" {
+				return version, fmt.Sprintf("%s %s (%s)", buildFile, p.Source, m[0])
+			}
+		}
+	}
+	return "", ""
+}
+
+// normalizeJavaVersion turns legacy versions such as "1.8" into "8".
+func normalizeJavaVersion(version string) string {
+	version = strings.TrimSpace(version)
+	if strings.HasPrefix(version, "1.") {
+		version = strings.TrimPrefix(version, "1.")
+	}
+	if version == "" || strings.ContainsAny(version, "${}") {
+		return ""
+	}
+	return strings.SplitN(version, ".", 2)[0]
+}
@@ -0,0 +1,75 @@
+package detector
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// laravelDetector recognises PHP Laravel projects from composer.json and artisan.
+type laravelDetector struct{}
+
+func (laravelDetector) Name() string { return string(ArchetypePHPLaravel) }
+
+func (laravelDetector) Detect(dirPath string) (*ProjectProfile, float64, []Evidence) {
+	composerPath := filepath.Join(dirPath, "composer.json")
+	if !fileExists(composerPath) || !fileExists(filepath.Join(dirPath, "artisan")) {
+		return nil, 0, nil
+	}
+
+	evidence := []Evidence{
+		{File: "composer.json", Detail: "composer.json present"},
+		{File: "artisan", Detail: "artisan console script present"},
+	}
+	confidence := 0.8
+	if line, text := findLine(composerPath, func(l string) bool { return strings.Contains(l, `"laravel/framework"`) }); line > 0 {
+		evidence = append(evidence, Evidence{File: "composer.json", Line: line, Detail: text})
+		confidence = 0.95
+	}
+
+	profile := &ProjectProfile{Archetype: ArchetypePHPLaravel}
+	version, err := parsePhpVersionFromComposer(composerPath)
+	if err != nil {
+		// Could not parse, but it's still a PHP project. Fallback to a default.
+		profile.LanguageVersion = "8.2"
+		profile.setSource("language_version", fmt.Sprintf("default (%v)", err))
+	} else {
+		profile.LanguageVersion = version
+		profile.setSource("language_version", "composer.json require.php")
+	}
+	return profile, confidence, evidence
+}
+
+// composer.json structure for PHP version
+type Composer struct {
+	Require map[string]string `json:"require"`
+}
+
+func parsePhpVersionFromComposer(composerPath string) (string, error) {
+	data, err := os.ReadFile(composerPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read composer.json: %w", err)
+	}
+
+	var composer Composer
+	if err := json.Unmarshal(data, &composer); err != nil {
+		return "", fmt.Errorf("failed to parse composer.json: %w", err)
+	}
+
+	phpConstraint := composer.Require["php"]
+	if phpConstraint == "" {
+		return "", fmt.Errorf("php version not found in composer.json 'require' section")
+	}
+
+	// Define a list of common PHP versions to check against
+	// In a real scenario, this might come from a configuration or a more dynamic source
+	phpVersions := []string{"7.4.0", "8.0.0", "8.1.0", "8.2.0", "8.3.0"}
+
+	version, err := resolveVersionConstraint(phpConstraint, phpVersions)
+	if err != nil {
+		return "", fmt.Errorf("invalid PHP version constraint in composer.json: %w", err)
+	}
+	return version, nil
+}
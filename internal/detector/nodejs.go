@@ -0,0 +1,482 @@
+package detector
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Node.js package managers recorded in ProjectProfile.PackageManager.
+const (
+	PackageManagerNPM  = "npm"
+	PackageManagerYarn = "yarn"
+	PackageManagerPNPM = "pnpm"
+	PackageManagerBun  = "bun"
+)
+
+// nodeVersions are the Node.js releases an engines.node range is resolved
+// against, oldest first. Each major is listed at its first and a late minor
+// so that ranges such as "^18.17.0" still match.
+var nodeVersions = []string{
+	"18.0.0", "18.20.0",
+	"20.0.0", "20.19.0",
+	"22.0.0", "22.16.0",
+	"24.0.0", "24.10.0",
+}
+
+// nodeLTSCodenames maps the LTS names accepted by nvm (e.g. "lts/iron") to their major version.
+var nodeLTSCodenames = map[string]string{
+	"hydrogen": "18",
+	"iron":     "20",
+	"jod":      "22",
+	"krypton":  "24",
+}
+
+// latestNodeLTS is what "lts/*" resolves to.
+const latestNodeLTS = "24"
+
+var yarnPathPattern = regexp.MustCompile(`yarn-(\d+)\.[\d.]+c?js`)
+
+// packageJSON holds the package.json fields the detectors read.
+type packageJSON struct {
+	Name            string            `json:"name"`
+	Main            string            `json:"main"`
+	Scripts         map[string]string `json:"scripts"`
+	Dependencies    map[string]string `json:"dependencies"`
+	DevDependencies map[string]string `json:"devDependencies"`
+	PackageManager  string            `json:"packageManager"`
+	Engines         map[string]string `json:"engines"`
+
+	// path is where the file was read from, for evidence line numbers.
+	path string
+}
+
+// readPackageJSON parses the package.json in dirPath.
+func readPackageJSON(dirPath string) (*packageJSON, error) {
+	path := filepath.Join(dirPath, "package.json")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pkg := &packageJSON{path: path}
+	if err := json.Unmarshal(content, pkg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return pkg, nil
+}
+
+// dependency reports whether name is declared in dependencies or
+// devDependencies, with the line that declares it as evidence.
+func (p *packageJSON) dependency(name string) (Evidence, bool) {
+	_, ok := p.Dependencies[name]
+	if !ok {
+		_, ok = p.DevDependencies[name]
+	}
+	if !ok {
+		return Evidence{}, false
+	}
+	line, text := p.keyLine(name)
+	if text == "" {
+		text = "package.json depends on " + name
+	}
+	return Evidence{File: "package.json", Line: line, Detail: text}, true
+}
+
+// firstDependency returns the first of names that is declared, in order.
+func (p *packageJSON) firstDependency(names ...string) (string, Evidence, bool) {
+	for _, name := range names {
+		if e, ok := p.dependency(name); ok {
+			return name, e, true
+		}
+	}
+	return "", Evidence{}, false
+}
+
+// script reports whether a script is defined, with the line that declares it as evidence.
+func (p *packageJSON) script(name string) (Evidence, bool) {
+	command, ok := p.Scripts[name]
+	if !ok {
+		return Evidence{}, false
+	}
+	line, _ := p.keyLine(name)
+	return Evidence{File: "package.json", Line: line, Detail: "scripts." + name + ": " + command}, true
+}
+
+// keyLine finds the line declaring a key such as a dependency or script name.
+// The line is 0 when the key does not start a line, e.g. in minified JSON.
+func (p *packageJSON) keyLine(key string) (int, string) {
+	return findLine(p.path, func(l string) bool {
+		return strings.HasPrefix(strings.TrimSpace(l), `"`+key+`"`)
+	})
+}
+
+// ssrFrameworkPackages are the packages that mark a full-stack framework.
+// Their projects often also depend on Vite or Express, so the SPA and server
+// detectors step aside for them.
+var ssrFrameworkPackages = []string{"next", "nuxt", "@remix-run/node", "@remix-run/react", "@sveltejs/kit"}
+
+// nextJSDetector recognises Next.js projects from package.json.
+type nextJSDetector struct{}
+
+func (nextJSDetector) Name() string { return string(ArchetypeNodeJSNextJS) }
+
+func (nextJSDetector) Detect(dirPath string) (*ProjectProfile, float64, []Evidence) {
+	pkg, err := readPackageJSON(dirPath)
+	if err != nil {
+		return nil, 0, nil
+	}
+	dependency, ok := pkg.dependency("next")
+	if !ok {
+		return nil, 0, nil
+	}
+
+	profile := &ProjectProfile{Archetype: ArchetypeNodeJSNextJS}
+	setNodeRuntime(profile, dirPath, pkg)
+	return profile, 0.85, []Evidence{dependency}
+}
+
+// nodeServerFrameworks are the backend frameworks recognised by nodeServerDetector, most specific first.
+var nodeServerFrameworks = []struct {
+	Package string
+	Name    string
+}{
+	{Package: "@nestjs/core", Name: "nestjs"},
+	{Package: "fastify", Name: "fastify"},
+	{Package: "express", Name: "express"},
+}
+
+// nodeServerDetector recognises backend Node.js servers built on Express, Fastify or NestJS.
+type nodeServerDetector struct{}
+
+func (nodeServerDetector) Name() string { return string(ArchetypeNodeJSServer) }
+
+func (nodeServerDetector) Detect(dirPath string) (*ProjectProfile, float64, []Evidence) {
+	pkg, err := readPackageJSON(dirPath)
+	if err != nil {
+		return nil, 0, nil
+	}
+
+	profile := &ProjectProfile{Archetype: ArchetypeNodeJSServer}
+	var evidence []Evidence
+	for _, fw := range nodeServerFrameworks {
+		if e, ok := pkg.dependency(fw.Package); ok {
+			profile.Framework = fw.Name
+			profile.setSource("framework", "package.json dependency "+fw.Package)
+			evidence = append(evidence, e)
+			break
+		}
+	}
+	if profile.Framework == "" {
+		return nil, 0, nil
+	}
+
+	confidence := 0.8
+	if _, _, ok := pkg.firstDependency(ssrFrameworkPackages...); ok {
+		// A full-stack framework with a custom server; let its own detector win.
+		confidence = 0.6
+	}
+
+	// The container runs the start script, preferring NestJS's production variant.
+	scripts := []string{"start"}
+	if profile.Framework == "nestjs" {
+		scripts = []string{"start:prod", "start"}
+	}
+	for _, name := range scripts {
+		if e, ok := pkg.script(name); ok {
+			profile.Entrypoint = name
+			profile.setSource("entrypoint", "package.json scripts."+name)
+			evidence = append(evidence, e)
+			break
+		}
+	}
+	if profile.Entrypoint == "" {
+		profile.Entrypoint = "start"
+		profile.setSource("entrypoint", "default (no start script in package.json)")
+		confidence -= 0.1
+	}
+
+	setNodeRuntime(profile, dirPath, pkg)
+	return profile, confidence, evidence
+}
+
+// nodeSSRFrameworks are the server-rendered frameworks recognised by nodeSSRDetector.
+var nodeSSRFrameworks = []struct {
+	Package string
+	Name    string
+}{
+	{Package: "nuxt", Name: "nuxt"},
+	{Package: "@remix-run/node", Name: "remix"},
+	{Package: "@sveltejs/kit", Name: "sveltekit"},
+}
+
+// nodeSSRDetector recognises server-rendered apps built with Nuxt, Remix or SvelteKit.
+type nodeSSRDetector struct{}
+
+func (nodeSSRDetector) Name() string { return string(ArchetypeNodeJSSSR) }
+
+func (nodeSSRDetector) Detect(dirPath string) (*ProjectProfile, float64, []Evidence) {
+	pkg, err := readPackageJSON(dirPath)
+	if err != nil {
+		return nil, 0, nil
+	}
+
+	profile := &ProjectProfile{Archetype: ArchetypeNodeJSSSR}
+	var evidence []Evidence
+	for _, fw := range nodeSSRFrameworks {
+		if e, ok := pkg.dependency(fw.Package); ok {
+			profile.Framework = fw.Name
+			profile.setSource("framework", "package.json dependency "+fw.Package)
+			evidence = append(evidence, e)
+			break
+		}
+	}
+	if profile.Framework == "" {
+		return nil, 0, nil
+	}
+
+	confidence := 0.85
+	if profile.Framework == "sveltekit" {
+		// Only adapter-node produces a server the container can run.
+		if e, ok := pkg.dependency("@sveltejs/adapter-node"); ok {
+			evidence = append(evidence, e)
+		} else {
+			confidence = 0.7
+		}
+	}
+
+	setNodeRuntime(profile, dirPath, pkg)
+	return profile, confidence, evidence
+}
+
+// viteOutDirPattern matches build.outDir in a Vite config file.
+var viteOutDirPattern = regexp.MustCompile(`outDir\s*:\s*['"]([^'"]+)['"]`)
+
+// nodeSPADetector recognises static single-page apps built with Vite,
+// Create React App or Angular. They are served by nginx, so Entrypoint
+// holds the directory the build writes to.
+type nodeSPADetector struct{}
+
+func (nodeSPADetector) Name() string { return string(ArchetypeNodeJSSPA) }
+
+func (nodeSPADetector) Detect(dirPath string) (*ProjectProfile, float64, []Evidence) {
+	pkg, err := readPackageJSON(dirPath)
+	if err != nil {
+		return nil, 0, nil
+	}
+	if _, _, ok := pkg.firstDependency(ssrFrameworkPackages...); ok {
+		return nil, 0, nil
+	}
+
+	profile := &ProjectProfile{Archetype: ArchetypeNodeJSSPA}
+	var evidence []Evidence
+	name, e, ok := pkg.firstDependency("@angular/core", "react-scripts", "vite")
+	if !ok {
+		return nil, 0, nil
+	}
+	profile.setSource("framework", "package.json dependency "+name)
+	switch {
+	case name == "@angular/core":
+		profile.Framework = "angular"
+		profile.Entrypoint = angularOutputPath(dirPath)
+		evidence = append(evidence, e)
+	case name == "react-scripts":
+		profile.Framework = "cra"
+		profile.Entrypoint = "build"
+		evidence = append(evidence, e)
+	default:
+		profile.Framework = "vite"
+		profile.Entrypoint = "dist"
+		evidence = append(evidence, e)
+		for _, config := range []string{"vite.config.ts", "vite.config.js", "vite.config.mjs"} {
+			if line, text := findLine(filepath.Join(dirPath, config), viteOutDirPattern.MatchString); line > 0 {
+				profile.Entrypoint = viteOutDirPattern.FindStringSubmatch(text)[1]
+				evidence = append(evidence, Evidence{File: config, Line: line, Detail: text})
+				break
+			}
+		}
+	}
+	profile.setSource("entrypoint", "build output of "+profile.Framework)
+
+	confidence := 0.8
+	if _, ok := pkg.dependency("express"); ok {
+		// The app has a backend of its own; make the choice explicit.
+		confidence = 0.7
+	}
+
+	setNodeRuntime(profile, dirPath, pkg)
+	return profile, confidence, evidence
+}
+
+// angularOutputPath reads the build output path of the first project in
+// angular.json. The application builder of Angular 17+ writes the browser
+// bundle to a "browser" subdirectory.
+func angularOutputPath(dirPath string) string {
+	var workspace struct {
+		Projects map[string]struct {
+			Architect map[string]struct {
+				Builder string `json:"builder"`
+				Options struct {
+					OutputPath json.RawMessage `json:"outputPath"`
+				} `json:"options"`
+			} `json:"architect"`
+		} `json:"projects"`
+		DefaultProject string `json:"defaultProject"`
+	}
+	content, err := os.ReadFile(filepath.Join(dirPath, "angular.json"))
+	if err != nil || json.Unmarshal(content, &workspace) != nil {
+		return "dist"
+	}
+
+	name := workspace.DefaultProject
+	if _, ok := workspace.Projects[name]; !ok {
+		names := make([]string, 0, len(workspace.Projects))
+		for n := range workspace.Projects {
+			names = append(names, n)
+		}
+		if len(names) == 0 {
+			return "dist"
+		}
+		sort.Strings(names)
+		name = names[0]
+	}
+
+	build := workspace.Projects[name].Architect["build"]
+	outputPath := "dist/" + name
+	var single string
+	var object struct {
+		Base    string  `json:"base"`
+		Browser *string `json:"browser"`
+	}
+	switch {
+	case json.Unmarshal(build.Options.OutputPath, &single) == nil && single != "":
+		outputPath = single
+	case json.Unmarshal(build.Options.OutputPath, &object) == nil && object.Base != "":
+		outputPath = object.Base
+		if object.Browser != nil {
+			return path.Join(outputPath, *object.Browser)
+		}
+	}
+	if strings.HasSuffix(build.Builder, ":application") {
+		return outputPath + "/browser"
+	}
+	return outputPath
+}
+
+// setNodeRuntime records the Node.js version and package manager of a project.
+func setNodeRuntime(profile *ProjectProfile, dirPath string, pkg *packageJSON) {
+	setNodeVersion(profile, dirPath, pkg)
+	setNodePackageManager(profile, dirPath, pkg)
+}
+
+func setNodeVersion(profile *ProjectProfile, dirPath string, pkg *packageJSON) {
+	version, source := nodeVersion(dirPath, pkg)
+	if version == "" {
+		version, source = "18", "default (no Node.js version declared)" // Default to Node 18
+	}
+	profile.LanguageVersion = version
+	profile.setSource("language_version", source)
+}
+
+// nodeVersion returns the Node.js major version pinned by .nvmrc or
+// .node-version, or else the lowest release satisfying engines.node.
+func nodeVersion(dirPath string, pkg *packageJSON) (string, string) {
+	for _, name := range []string{".nvmrc", ".node-version"} {
+		content, err := os.ReadFile(filepath.Join(dirPath, name))
+		if err != nil {
+			continue
+		}
+		if version := parseNodeVersionFile(string(content)); version != "" {
+			return version, name
+		}
+	}
+
+	if constraint := strings.TrimSpace(pkg.Engines["node"]); constraint != "" {
+		if version, err := resolveVersionConstraint(constraint, nodeVersions); err == nil {
+			return strings.SplitN(version, ".", 2)[0], fmt.Sprintf("package.json engines.node (%s)", constraint)
+		}
+	}
+	return "", ""
+}
+
+// parseNodeVersionFile reads the major version from an .nvmrc or .node-version
+// file, which may hold "20", "v20.11.1" or an LTS alias such as "lts/iron".
+func parseNodeVersionFile(content string) string {
+	value := strings.ToLower(strings.TrimSpace(strings.SplitN(content, "\n", 2)[0]))
+	if alias, ok := strings.CutPrefix(value, "lts/"); ok {
+		if alias == "*" {
+			return latestNodeLTS
+		}
+		return nodeLTSCodenames[alias]
+	}
+
+	major := strings.SplitN(strings.TrimPrefix(value, "v"), ".", 2)[0]
+	for _, r := range major {
+		if r < '0' || r > '9' {
+			return ""
+		}
+	}
+	return major
+}
+
+type nodeLockfile struct{ file, manager string }
+
+// nodeLockfiles are the lockfiles of each package manager, in the order they are looked for.
+var nodeLockfiles = []nodeLockfile{
+	{"pnpm-lock.yaml", PackageManagerPNPM},
+	{"yarn.lock", PackageManagerYarn},
+	{"bun.lock", PackageManagerBun},
+	{"bun.lockb", PackageManagerBun},
+	{"package-lock.json", PackageManagerNPM},
+	{"npm-shrinkwrap.json", PackageManagerNPM},
+}
+
+// setNodePackageManager records the package manager named by the packageManager
+// field (used by Corepack), or else the one whose lockfile is present.
+func setNodePackageManager(profile *ProjectProfile, dirPath string, pkg *packageJSON) {
+	// Frozen installs refuse to run without the manager's lockfile, so the builds resolve the dependencies instead.
+	defer func() {
+		profile.NoLockfile = !slices.ContainsFunc(nodeLockfiles, func(lock nodeLockfile) bool {
+			return lock.manager == profile.PackageManager && fileExists(filepath.Join(dirPath, lock.file))
+		})
+	}()
+
+	if name, version, ok := strings.Cut(pkg.PackageManager, "@"); ok {
+		switch name {
+		case PackageManagerNPM, PackageManagerYarn, PackageManagerPNPM, PackageManagerBun:
+			profile.PackageManager = name
+			profile.PackageManagerVersion = strings.SplitN(version, ".", 2)[0]
+			profile.setSource("package_manager", "package.json packageManager ("+pkg.PackageManager+")")
+			return
+		}
+	}
+
+	for _, lock := range nodeLockfiles {
+		if fileExists(filepath.Join(dirPath, lock.file)) {
+			profile.PackageManager = lock.manager
+			profile.setSource("package_manager", lock.file+" present")
+			break
+		}
+	}
+	if profile.PackageManager == "" {
+		profile.PackageManager = PackageManagerNPM
+		profile.setSource("package_manager", "default (no lockfile)")
+	}
+
+	// Yarn 2+ projects without a packageManager field usually check in the
+	// release they use and point yarnPath at it.
+	if profile.PackageManager == PackageManagerYarn {
+		if _, text := findLine(filepath.Join(dirPath, ".yarnrc.yml"), func(l string) bool {
+			return strings.HasPrefix(strings.TrimSpace(l), "yarnPath:")
+		}); text != "" {
+			if m := yarnPathPattern.FindStringSubmatch(text); m != nil {
+				profile.PackageManagerVersion = m[1]
+			}
+		}
+	}
+}
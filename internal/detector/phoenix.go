@@ -0,0 +1,118 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// elixirVersions are the Elixir releases a mix.exs requirement is resolved against, oldest first.
+var elixirVersions = []string{"1.14.0", "1.15.0", "1.16.0", "1.17.0", "1.18.0"}
+
+// defaultOTPVersions is the newest Erlang/OTP release each Elixir version
+// supports, used when the project does not pin one.
+var defaultOTPVersions = map[string]string{"1.14": "25", "1.15": "26", "1.16": "26", "1.17": "27", "1.18": "27"}
+
+var (
+	// mixAppPattern matches the OTP application name, e.g. `app: :my_app`.
+	mixAppPattern = regexp.MustCompile(`\bapp:\s*:(\w+)`)
+	// mixModulePattern matches the project module, e.g. `defmodule MyApp.MixProject do`.
+	mixModulePattern = regexp.MustCompile(`^\s*defmodule\s+([\w.]+)\.MixProject\b`)
+	// mixElixirPattern matches the Elixir requirement, e.g. `elixir: "~> 1.14"`.
+	mixElixirPattern = regexp.MustCompile(`\belixir:\s*"([^"]+)"`)
+)
+
+// phoenixDetector recognises Elixir Phoenix projects from mix.exs.
+type phoenixDetector struct{}
+
+func (phoenixDetector) Name() string { return string(ArchetypeElixirPhoenix) }
+
+func (phoenixDetector) Detect(dirPath string) (*ProjectProfile, float64, []Evidence) {
+	mixPath := filepath.Join(dirPath, "mix.exs")
+	line, text := findLine(mixPath, func(l string) bool { return strings.Contains(l, "{:phoenix,") })
+	if line == 0 {
+		return nil, 0, nil
+	}
+
+	evidence := []Evidence{{File: "mix.exs", Line: line, Detail: text}}
+	profile := &ProjectProfile{Archetype: ArchetypeElixirPhoenix, Framework: "phoenix"}
+
+	// The release is named after the OTP application.
+	if line, text := findLine(mixPath, mixAppPattern.MatchString); line > 0 {
+		profile.Entrypoint = mixAppPattern.FindStringSubmatch(text)[1]
+		profile.setSource("entrypoint", "mix.exs app")
+	} else {
+		profile.Entrypoint = "app"
+		profile.setSource("entrypoint", "default (no app name in mix.exs)")
+	}
+
+	// Apps generated with Ecto migrate through <Module>.Release.migrate, which
+	// `mix phx.gen.release` also wraps in a bin/migrate script.
+	if line, text := findLine(mixPath, func(l string) bool { return strings.Contains(l, "{:ecto_sql,") }); line > 0 {
+		evidence = append(evidence, Evidence{File: "mix.exs", Line: line, Detail: text})
+		if fileExists(filepath.Join(dirPath, "rel", "overlays", "bin", "migrate")) {
+			profile.MigrationCommand = "/app/bin/migrate"
+			profile.setSource("migration_command", "rel/overlays/bin/migrate")
+		} else if _, text := findLine(mixPath, mixModulePattern.MatchString); text != "" {
+			module := mixModulePattern.FindStringSubmatch(text)[1]
+			profile.MigrationCommand = "/app/bin/" + profile.Entrypoint + ` eval "` + module + `.Release.migrate"`
+			profile.setSource("migration_command", "mix.exs module "+module)
+		}
+	}
+
+	elixir, otp, source := elixirVersion(dirPath, mixPath)
+	if elixir == "" {
+		elixir, source = "1.17", "default (no Elixir version declared)" // Default to Elixir 1.17
+	}
+	profile.LanguageVersion = elixir
+	profile.setSource("language_version", source)
+	if otp == "" {
+		otp = defaultOTPVersions[elixir]
+		if otp == "" {
+			otp = "27" // Default to OTP 27
+		}
+		profile.setSource("runtime_version", "default for Elixir "+elixir+" (no Erlang/OTP version declared)")
+	} else {
+		profile.setSource("runtime_version", ".tool-versions")
+	}
+	profile.RuntimeVersion = otp
+	return profile, 0.95, evidence
+}
+
+// elixirVersion reads the Elixir and Erlang/OTP versions from .tool-versions,
+// falling back to the elixir requirement in mix.exs. The OTP release is only
+// known from .tool-versions, either as an erlang entry or an "-otp-26" suffix.
+func elixirVersion(dirPath, mixPath string) (string, string, string) {
+	elixir, otp := "", ""
+	if content, err := os.ReadFile(filepath.Join(dirPath, ".tool-versions")); err == nil {
+		for _, line := range strings.Split(string(content), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) < 2 {
+				continue
+			}
+			switch fields[0] {
+			case "elixir":
+				version, suffix, _ := strings.Cut(fields[1], "-otp-")
+				elixir = majorMinor(version)
+				if suffix != "" && otp == "" {
+					otp = suffix
+				}
+			case "erlang":
+				otp = strings.SplitN(fields[1], ".", 2)[0]
+			}
+		}
+	}
+	if elixir != "" {
+		return elixir, otp, ".tool-versions"
+	}
+
+	if _, text := findLine(mixPath, mixElixirPattern.MatchString); text != "" {
+		requirement := mixElixirPattern.FindStringSubmatch(text)[1]
+		// Mix's "~>" has the same lower bound as semver's "~".
+		if version, err := resolveVersionConstraint(strings.ReplaceAll(requirement, "~>", "~"), elixirVersions); err == nil {
+			return version, otp, "mix.exs elixir requirement (" + requirement + ")"
+		}
+	}
+	return "", otp, ""
+}
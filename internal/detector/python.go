@@ -0,0 +1,155 @@
+package detector
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// pythonVersions are the interpreter versions a requires-python style
+// constraint is resolved against, oldest first.
+var pythonVersions = []string{"3.8.0", "3.9.0", "3.10.0", "3.11.0", "3.12.0", "3.13.0"}
+
+// fastAPIDetector recognises Python FastAPI projects from their declared dependencies.
+type fastAPIDetector struct{}
+
+func (fastAPIDetector) Name() string { return string(ArchetypePythonFastAPI) }
+
+func (fastAPIDetector) Detect(dirPath string) (*ProjectProfile, float64, []Evidence) {
+	project := parsePythonProject(dirPath)
+	if project == nil {
+		return nil, 0, nil
+	}
+	dependency, ok := project.Has("fastapi")
+	if !ok {
+		return nil, 0, nil
+	}
+
+	evidence := []Evidence{dependency, project.ManagerEvidence}
+	profile := &ProjectProfile{Archetype: ArchetypePythonFastAPI}
+	if entrypoint, e := fastAPIEntrypoint(dirPath); entrypoint != "" {
+		profile.Entrypoint = entrypoint
+		profile.setSource("entrypoint", e.File)
+		evidence = append(evidence, e)
+	} else {
+		profile.Entrypoint = "main:app"
+		profile.setSource("entrypoint", "default (no FastAPI application found)")
+	}
+	setPythonVersion(profile, dirPath)
+	setPackageManager(profile, project)
+	return profile, 0.9, evidence
+}
+
+// fastAPIAppPattern matches a module-level application such as `app = FastAPI()`.
+var fastAPIAppPattern = regexp.MustCompile(`^(\w+)\s*(?::\s*\w+\s*)?=\s*FastAPI\(`)
+
+// fastAPIModules are the files checked for the FastAPI application, in order.
+var fastAPIModules = []string{"main.py", "app.py", "application.py", "server.py", "api.py", "app/main.py", "src/main.py"}
+
+// fastAPIEntrypoint finds the FastAPI application and returns it in uvicorn's
+// "module:variable" form. Common module names are checked first, then the
+// main.py and __init__.py of each package.
+func fastAPIEntrypoint(dirPath string) (string, Evidence) {
+	candidates := append([]string{}, fastAPIModules...)
+	if entries, err := os.ReadDir(dirPath); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+				candidates = append(candidates, entry.Name()+"/main.py", entry.Name()+"/__init__.py")
+			}
+		}
+	}
+
+	for _, candidate := range candidates {
+		if line, text := findLine(filepath.Join(dirPath, candidate), fastAPIAppPattern.MatchString); line > 0 {
+			module := strings.ReplaceAll(strings.TrimSuffix(strings.TrimSuffix(candidate, "/__init__.py"), ".py"), "/", ".")
+			return module + ":" + fastAPIAppPattern.FindStringSubmatch(text)[1], Evidence{File: candidate, Line: line, Detail: text}
+		}
+	}
+	return "", Evidence{}
+}
+
+// setPackageManager records the dependency manager found by parsePythonProject.
+func setPackageManager(profile *ProjectProfile, project *pythonProject) {
+	profile.PackageManager = project.Manager
+	profile.NoLockfile = project.NoLockfile
+	profile.setSource("package_manager", project.ManagerEvidence.Detail)
+}
+
+// setPythonVersion resolves the Python version from, in priority order:
+// .python-version, pyproject.toml requires-python, runtime.txt, Pipfile and
+// setup.cfg. It falls back to 3.9 when none of them declares one.
+func setPythonVersion(profile *ProjectProfile, dirPath string) {
+	version, source := pythonVersion(dirPath)
+	if version == "" {
+		version, source = "3.9", "default (no Python version declared)" // Default to Python 3.9
+	}
+	profile.LanguageVersion = version
+	profile.setSource("language_version", source)
+}
+
+var runtimeTxtPattern = regexp.MustCompile(`^python-(\d+\.\d+)`)
+
+func pythonVersion(dirPath string) (string, string) {
+	if content, err := os.ReadFile(filepath.Join(dirPath, ".python-version")); err == nil {
+		// pyenv allows several versions, one per line; the first one is the default.
+		first := strings.TrimSpace(strings.SplitN(string(content), "\n", 2)[0])
+		if first != "" {
+			return majorMinor(first), ".python-version"
+		}
+	}
+
+	pyprojectPath := filepath.Join(dirPath, "pyproject.toml")
+	for _, key := range []struct{ section, name string }{
+		{"project", "requires-python"},
+		{"tool.poetry.dependencies", "python"},
+	} {
+		if constraint, _ := sectionValue(pyprojectPath, key.section, key.name); constraint != "" {
+			if version, err := resolveVersionConstraint(pep440ToSemver(constraint), pythonVersions); err == nil {
+				return version, fmt.Sprintf("pyproject.toml %s (%s)", key.name, constraint)
+			}
+		}
+	}
+
+	if content, err := os.ReadFile(filepath.Join(dirPath, "runtime.txt")); err == nil {
+		if m := runtimeTxtPattern.FindStringSubmatch(strings.TrimSpace(string(content))); m != nil {
+			return m[1], "runtime.txt"
+		}
+	}
+
+	if version, _ := sectionValue(filepath.Join(dirPath, "Pipfile"), "requires", "python_version"); version != "" {
+		return majorMinor(version), "Pipfile [requires] python_version"
+	}
+	if version, _ := sectionValue(filepath.Join(dirPath, "Pipfile"), "requires", "python_full_version"); version != "" {
+		return majorMinor(version), "Pipfile [requires] python_full_version"
+	}
+
+	if constraint, _ := sectionValue(filepath.Join(dirPath, "setup.cfg"), "options", "python_requires"); constraint != "" {
+		if version, err := resolveVersionConstraint(pep440ToSemver(constraint), pythonVersions); err == nil {
+			return version, fmt.Sprintf("setup.cfg python_requires (%s)", constraint)
+		}
+	}
+	return "", ""
+}
+
+// pep440ToSemver rewrites the common PEP 440 specifiers into the semver
+// constraint syntax understood by resolveVersionConstraint.
+func pep440ToSemver(constraint string) string {
+	var parts []string
+	for _, part := range strings.Split(constraint, ",") {
+		part = strings.TrimSpace(part)
+		switch {
+		case strings.HasPrefix(part, "~="):
+			// Compatible release: ~=3.10 means >=3.10, ==3.*
+			parts = append(parts, ">="+strings.TrimSpace(part[2:]))
+		case strings.HasPrefix(part, "==") && strings.HasSuffix(part, ".*"):
+			parts = append(parts, strings.TrimSuffix(strings.TrimSpace(part[2:]), ".*")+".x")
+		case strings.HasPrefix(part, "=="):
+			parts = append(parts, "="+strings.TrimSpace(part[2:]))
+		case part != "":
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
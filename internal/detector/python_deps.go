@@ -0,0 +1,126 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Python dependency managers recorded in ProjectProfile.PackageManager.
+const (
+	PackageManagerPip    = "pip"
+	PackageManagerPoetry = "poetry"
+	PackageManagerUV     = "uv"
+	PackageManagerPipenv = "pipenv"
+	PackageManagerPDM    = "pdm"
+)
+
+// pythonProject is what the Python detectors know about a project's dependencies.
+type pythonProject struct {
+	Manager string
+	// ManagerEvidence is the file that identified the dependency manager.
+	ManagerEvidence Evidence
+	// NoLockfile is set when only pyproject.toml lists the dependencies, so pip installs the project itself.
+	NoLockfile bool
+	// Dependencies maps each normalized package name to where it was declared.
+	Dependencies map[string]Evidence
+}
+
+// Has reports whether a package is declared, using its normalized name.
+func (p *pythonProject) Has(name string) (Evidence, bool) {
+	e, ok := p.Dependencies[normalizePythonName(name)]
+	return e, ok
+}
+
+// parsePythonProject identifies the dependency manager and collects the
+// declared dependencies from requirements.txt, pyproject.toml (PEP 621 and
+// Poetry) and Pipfile. It returns nil when the directory has none of them.
+func parsePythonProject(dirPath string) *pythonProject {
+	project := &pythonProject{Dependencies: map[string]Evidence{}}
+	pyprojectPath := filepath.Join(dirPath, "pyproject.toml")
+	pipfilePath := filepath.Join(dirPath, "Pipfile")
+	requirementsPath := filepath.Join(dirPath, "requirements.txt")
+
+	// Lockfiles are the strongest signal, then Pipfile and Poetry's section in
+	// pyproject.toml. uv and PDM are only chosen by their lockfiles: the
+	// generated builds install with --frozen, which needs one.
+	switch {
+	case fileExists(filepath.Join(dirPath, "uv.lock")):
+		project.Manager, project.ManagerEvidence = PackageManagerUV, Evidence{File: "uv.lock", Detail: "uv.lock present"}
+	case fileExists(filepath.Join(dirPath, "poetry.lock")):
+		project.Manager, project.ManagerEvidence = PackageManagerPoetry, Evidence{File: "poetry.lock", Detail: "poetry.lock present"}
+	case fileExists(filepath.Join(dirPath, "pdm.lock")):
+		project.Manager, project.ManagerEvidence = PackageManagerPDM, Evidence{File: "pdm.lock", Detail: "pdm.lock present"}
+	case fileExists(pipfilePath):
+		project.Manager, project.ManagerEvidence = PackageManagerPipenv, Evidence{File: "Pipfile", Detail: "Pipfile present"}
+	case hasSection(pyprojectPath, "tool.poetry"):
+		project.Manager, project.ManagerEvidence = PackageManagerPoetry, Evidence{File: "pyproject.toml", Detail: "[tool.poetry] section"}
+	case fileExists(requirementsPath):
+		project.Manager, project.ManagerEvidence = PackageManagerPip, Evidence{File: "requirements.txt", Detail: "requirements.txt present"}
+	case fileExists(pyprojectPath):
+		project.Manager, project.ManagerEvidence = PackageManagerPip, Evidence{File: "pyproject.toml", Detail: "pyproject.toml present (no lockfile)"}
+		project.NoLockfile = true
+	default:
+		return nil
+	}
+
+	project.addRequirements(requirementsPath)
+	for _, item := range sectionArray(pyprojectPath, "project", "dependencies") {
+		project.add(requirementName(item.Value), Evidence{File: "pyproject.toml", Line: item.Line, Detail: item.Value})
+	}
+	for _, item := range sectionKeys(pyprojectPath, "tool.poetry.dependencies") {
+		if item.Value != "python" {
+			project.add(item.Value, Evidence{File: "pyproject.toml", Line: item.Line, Detail: "[tool.poetry.dependencies] " + item.Value})
+		}
+	}
+	for _, item := range sectionKeys(pipfilePath, "packages") {
+		project.add(item.Value, Evidence{File: "Pipfile", Line: item.Line, Detail: "[packages] " + item.Value})
+	}
+	return project
+}
+
+func (p *pythonProject) add(name string, e Evidence) {
+	name = normalizePythonName(name)
+	if _, seen := p.Dependencies[name]; name != "" && !seen {
+		p.Dependencies[name] = e
+	}
+}
+
+// addRequirements reads a pip requirements file, skipping comments and options such as -r or -e.
+func (p *pythonProject) addRequirements(path string) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	for i, raw := range strings.Split(string(content), "\n") {
+		line := strings.TrimSpace(raw)
+		if hash := strings.Index(line, "#"); hash >= 0 {
+			line = strings.TrimSpace(line[:hash])
+		}
+		if line == "" || strings.HasPrefix(line, "-") {
+			continue
+		}
+		p.add(requirementName(line), Evidence{File: filepath.Base(path), Line: i + 1, Detail: line})
+	}
+}
+
+// requirementName extracts the package name from a PEP 508 requirement such as "uvicorn[standard]>=0.23".
+func requirementName(requirement string) string {
+	end := strings.IndexAny(requirement, " [<>=!~;@(")
+	if end >= 0 {
+		requirement = requirement[:end]
+	}
+	return strings.TrimSpace(requirement)
+}
+
+// normalizePythonName applies PEP 503 name normalization.
+func normalizePythonName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer("_", "-", ".", "-").Replace(name)
+}
+
+// hasSection reports whether a TOML file has the given [section] header.
+func hasSection(path, section string) bool {
+	line, _ := findLine(path, func(l string) bool { return strings.TrimSpace(l) == "["+section+"]" })
+	return line > 0
+}
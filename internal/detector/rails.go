@@ -0,0 +1,95 @@
+package detector
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// rubyVersions are the Ruby releases a Gemfile `ruby` requirement is resolved against, oldest first.
+var rubyVersions = []string{"3.0.0", "3.1.0", "3.2.0", "3.3.0", "3.4.0"}
+
+var (
+	// gemfileRailsPattern matches `gem "rails"` in a Gemfile.
+	gemfileRailsPattern = regexp.MustCompile(`^\s*gem\s+['"]rails['"]`)
+	// gemfileRubyPattern matches the `ruby "3.2.2"` directive in a Gemfile.
+	gemfileRubyPattern = regexp.MustCompile(`^\s*ruby\s+['"]([^'"]+)['"]`)
+	// lockRailsPattern matches the resolved rails gem in Gemfile.lock.
+	lockRailsPattern = regexp.MustCompile(`^\s{4}rails \(([\d.]+)\)`)
+	// lockRubyPattern matches the line under RUBY VERSION in Gemfile.lock, e.g. "ruby 3.2.2p53".
+	lockRubyPattern = regexp.MustCompile(`^\s+ruby (\d+\.\d+)`)
+)
+
+// railsDetector recognises Ruby on Rails projects from the Gemfile and config/application.rb.
+type railsDetector struct{}
+
+func (railsDetector) Name() string { return string(ArchetypeRubyRails) }
+
+func (railsDetector) Detect(dirPath string) (*ProjectProfile, float64, []Evidence) {
+	gemfilePath := filepath.Join(dirPath, "Gemfile")
+	lockPath := filepath.Join(dirPath, "Gemfile.lock")
+
+	var evidence []Evidence
+	if line, text := findLine(gemfilePath, gemfileRailsPattern.MatchString); line > 0 {
+		evidence = append(evidence, Evidence{File: "Gemfile", Line: line, Detail: text})
+	} else if line, text := findLine(lockPath, lockRailsPattern.MatchString); line > 0 {
+		// Apps that depend on the rails components individually still resolve the rails gem.
+		evidence = append(evidence, Evidence{File: "Gemfile.lock", Line: line, Detail: text})
+	} else {
+		return nil, 0, nil
+	}
+
+	confidence := 0.75
+	if line, text := findLine(filepath.Join(dirPath, "config", "application.rb"), func(l string) bool {
+		return strings.Contains(l, "Rails::Application")
+	}); line > 0 {
+		evidence = append(evidence, Evidence{File: "config/application.rb", Line: line, Detail: text})
+		confidence = 0.95
+	}
+
+	profile := &ProjectProfile{Archetype: ArchetypeRubyRails, Framework: "rails"}
+	version, source := rubyVersion(dirPath)
+	if version == "" {
+		version, source = "3.3", "default (no Ruby version declared)" // Default to Ruby 3.3
+	}
+	profile.LanguageVersion = version
+	profile.setSource("language_version", source)
+	return profile, confidence, evidence
+}
+
+// rubyVersion resolves the Ruby version from .ruby-version, the Gemfile
+// `ruby` directive or the RUBY VERSION section of Gemfile.lock.
+func rubyVersion(dirPath string) (string, string) {
+	if content, err := os.ReadFile(filepath.Join(dirPath, ".ruby-version")); err == nil {
+		value := strings.TrimSpace(strings.SplitN(string(content), "\n", 2)[0])
+		if value = strings.TrimPrefix(value, "ruby-"); value != "" {
+			return majorMinor(value), ".ruby-version"
+		}
+	}
+
+	if _, text := findLine(filepath.Join(dirPath, "Gemfile"), gemfileRubyPattern.MatchString); text != "" {
+		requirement := gemfileRubyPattern.FindStringSubmatch(text)[1]
+		if requirement[0] >= '0' && requirement[0] <= '9' {
+			return majorMinor(requirement), "Gemfile ruby directive"
+		}
+		// Bundler's pessimistic operator "~>" has the same lower bound as semver's "~".
+		constraint := strings.ReplaceAll(requirement, "~>", "~")
+		if version, err := resolveVersionConstraint(constraint, rubyVersions); err == nil {
+			return version, fmt.Sprintf("Gemfile ruby directive (%s)", requirement)
+		}
+	}
+
+	if content, err := os.ReadFile(filepath.Join(dirPath, "Gemfile.lock")); err == nil {
+		lines := strings.Split(string(content), "\n")
+		for i, line := range lines {
+			if strings.TrimSpace(line) == "RUBY VERSION" && i+1 < len(lines) {
+				if m := lockRubyPattern.FindStringSubmatch(lines[i+1]); m != nil {
+					return m[1], "Gemfile.lock RUBY VERSION"
+				}
+			}
+		}
+	}
+	return "", ""
+}
@@ -0,0 +1,138 @@
+package detector
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// rustFrameworks are the web framework crates recognised by rustDetector, in priority order.
+var rustFrameworks = []string{"axum", "actix-web", "rocket", "warp", "poem"}
+
+// rustDetector recognises Rust services from Cargo.toml.
+type rustDetector struct{}
+
+func (rustDetector) Name() string { return string(ArchetypeRustService) }
+
+func (rustDetector) Detect(dirPath string) (*ProjectProfile, float64, []Evidence) {
+	cargoPath := filepath.Join(dirPath, "Cargo.toml")
+	if !fileExists(cargoPath) {
+		return nil, 0, nil
+	}
+
+	profile := &ProjectProfile{Archetype: ArchetypeRustService}
+	evidence := []Evidence{{File: "Cargo.toml", Detail: "Cargo.toml present"}}
+	confidence := 0.7
+
+	// In a workspace, the service is the first member that builds a binary.
+	crateDir := "."
+	if _, line := sectionValue(cargoPath, "package", "name"); line == 0 {
+		for _, member := range sectionArray(cargoPath, "workspace", "members") {
+			if !strings.ContainsAny(member.Value, "*?[") && rustBinary(filepath.Join(dirPath, member.Value)) != "" {
+				crateDir = member.Value
+				evidence = append(evidence, Evidence{File: "Cargo.toml", Line: member.Line, Detail: "workspace member " + member.Value})
+				break
+			}
+		}
+	}
+	crateCargo := filepath.ToSlash(filepath.Join(crateDir, "Cargo.toml"))
+	cratePath := filepath.Join(dirPath, crateDir, "Cargo.toml")
+
+	for _, crate := range rustFrameworks {
+		if line, text := rustDependency(cratePath, crate); line > 0 {
+			profile.Framework = crate
+			profile.setSource("framework", crateCargo+" dependency "+crate)
+			evidence = append(evidence, Evidence{File: crateCargo, Line: line, Detail: text})
+			confidence = 0.9
+			break
+		}
+	}
+
+	if binary := rustBinary(filepath.Join(dirPath, crateDir)); binary != "" {
+		profile.Entrypoint = binary
+		profile.setSource("entrypoint", crateCargo+" binary "+binary)
+	} else {
+		// A crate without a binary target is most likely a library.
+		profile.Entrypoint = "app"
+		profile.setSource("entrypoint", "default (no binary target found)")
+		confidence -= 0.2
+	}
+
+	version, source := rustVersion(dirPath, cratePath, crateCargo)
+	if version == "" {
+		version, source = "1.82", "default (no Rust version declared)" // Default to Rust 1.82
+	}
+	profile.LanguageVersion = version
+	profile.setSource("language_version", source)
+	return profile, confidence, evidence
+}
+
+// rustDependency finds a crate in [dependencies], either as a key or as a
+// [dependencies.<crate>] table.
+func rustDependency(cargoPath, crate string) (int, string) {
+	for _, key := range sectionKeys(cargoPath, "dependencies") {
+		if key.Value == crate {
+			_, text := findLine(cargoPath, func(l string) bool {
+				name, _, ok := strings.Cut(l, "=")
+				return ok && strings.TrimSpace(name) == crate
+			})
+			return key.Line, text
+		}
+	}
+	return findLine(cargoPath, func(l string) bool {
+		return strings.TrimSpace(l) == "[dependencies."+crate+"]"
+	})
+}
+
+// rustBinary returns the name of the binary a crate builds: the package name
+// when src/main.rs exists, else the first [[bin]] target, else the first file
+// in src/bin. It returns "" for library crates.
+func rustBinary(crateDir string) string {
+	cargoPath := filepath.Join(crateDir, "Cargo.toml")
+	if name, _ := sectionValue(cargoPath, "package", "name"); name != "" && fileExists(filepath.Join(crateDir, "src", "main.rs")) {
+		return name
+	}
+	if name, _ := sectionValue(cargoPath, "bin", "name"); name != "" {
+		return name
+	}
+	if entries, err := os.ReadDir(filepath.Join(crateDir, "src", "bin")); err == nil {
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".rs") {
+				return strings.TrimSuffix(entry.Name(), ".rs")
+			}
+		}
+	}
+	return ""
+}
+
+// rustVersion resolves the Rust toolchain from rust-toolchain.toml, the legacy
+// rust-toolchain file or the rust-version field of Cargo.toml. Channel names
+// such as "stable" carry no version and are skipped.
+func rustVersion(dirPath, cratePath, crateCargo string) (string, string) {
+	if channel, _ := sectionValue(filepath.Join(dirPath, "rust-toolchain.toml"), "toolchain", "channel"); isRustVersion(channel) {
+		return majorMinor(channel), "rust-toolchain.toml channel"
+	}
+	if content, err := os.ReadFile(filepath.Join(dirPath, "rust-toolchain")); err == nil {
+		if channel := strings.TrimSpace(string(content)); isRustVersion(channel) {
+			return majorMinor(channel), "rust-toolchain"
+		}
+	}
+	// A workspace member may inherit rust-version from [workspace.package] in the root manifest.
+	for _, manifest := range []struct{ path, name string }{
+		{cratePath, crateCargo},
+		{filepath.Join(dirPath, "Cargo.toml"), "Cargo.toml"},
+	} {
+		for _, section := range []string{"package", "workspace.package"} {
+			if version, _ := sectionValue(manifest.path, section, "rust-version"); isRustVersion(version) {
+				return majorMinor(version), fmt.Sprintf("%s rust-version (%s)", manifest.name, version)
+			}
+		}
+	}
+	return "", ""
+}
+
+// isRustVersion reports whether a toolchain channel is a numbered release such as "1.78.0".
+func isRustVersion(channel string) bool {
+	return strings.HasPrefix(channel, "1.")
+}
@@ -0,0 +1,129 @@
+package detector
+
+import (
+	"path/filepath"
+	"regexp"
+)
+
+// Backing service types recorded in ProjectProfile.BackingServices.
+const (
+	ServiceRedis         = "redis"
+	ServiceMemcached     = "memcached"
+	ServiceRabbitMQ      = "rabbitmq"
+	ServiceKafka         = "kafka"
+	ServiceElasticsearch = "elasticsearch"
+)
+
+// Kinds of backing service.
+const (
+	KindCache  = "cache"
+	KindQueue  = "queue"
+	KindSearch = "search"
+)
+
+// serviceKinds gives the kind of each backing service type.
+var serviceKinds = map[string]string{
+	ServiceRedis:         KindCache,
+	ServiceMemcached:     KindCache,
+	ServiceRabbitMQ:      KindQueue,
+	ServiceKafka:         KindQueue,
+	ServiceElasticsearch: KindSearch,
+}
+
+// BackingService is a cache, queue or search engine the project's dependencies point to.
+type BackingService struct {
+	Type     string   `yaml:"type" json:"type"`
+	Kind     string   `yaml:"kind" json:"kind"`
+	Evidence Evidence `yaml:"evidence" json:"evidence"`
+}
+
+var pythonServiceClients = []packageRule{
+	{"redis", ServiceRedis}, {"django-redis", ServiceRedis}, {"rq", ServiceRedis},
+	{"pymemcache", ServiceMemcached}, {"python-memcached", ServiceMemcached},
+	// Celery's default broker is RabbitMQ; projects using Redis also depend on redis.
+	{"celery", ServiceRabbitMQ}, {"pika", ServiceRabbitMQ}, {"aio-pika", ServiceRabbitMQ}, {"kombu", ServiceRabbitMQ},
+	{"kafka-python", ServiceKafka}, {"confluent-kafka", ServiceKafka}, {"aiokafka", ServiceKafka},
+	{"elasticsearch", ServiceElasticsearch}, {"elasticsearch-dsl", ServiceElasticsearch},
+}
+
+var nodeServiceClients = []packageRule{
+	{"redis", ServiceRedis}, {"ioredis", ServiceRedis}, {"bull", ServiceRedis}, {"bullmq", ServiceRedis},
+	{"memjs", ServiceMemcached},
+	{"amqplib", ServiceRabbitMQ}, {"amqp-connection-manager", ServiceRabbitMQ},
+	{"kafkajs", ServiceKafka},
+	{"@elastic/elasticsearch", ServiceElasticsearch},
+}
+
+var serviceRules = []manifestRule{
+	// Maven and Gradle
+	{"pom.xml", regexp.MustCompile(`spring-boot-starter-data-redis|jedis|lettuce-core`), ServiceRedis},
+	{"pom.xml", regexp.MustCompile(`spring-boot-starter-amqp|amqp-client`), ServiceRabbitMQ},
+	{"pom.xml", regexp.MustCompile(`spring-kafka|kafka-clients`), ServiceKafka},
+	{"pom.xml", regexp.MustCompile(`spring-boot-starter-data-elasticsearch|elasticsearch-java`), ServiceElasticsearch},
+	{"build.gradle", regexp.MustCompile(`spring-boot-starter-data-redis|jedis|lettuce-core`), ServiceRedis},
+	{"build.gradle", regexp.MustCompile(`spring-boot-starter-amqp|amqp-client`), ServiceRabbitMQ},
+	{"build.gradle", regexp.MustCompile(`spring-kafka|kafka-clients`), ServiceKafka},
+	{"build.gradle", regexp.MustCompile(`spring-boot-starter-data-elasticsearch|elasticsearch-java`), ServiceElasticsearch},
+	{"build.gradle.kts", regexp.MustCompile(`spring-boot-starter-data-redis|jedis|lettuce-core`), ServiceRedis},
+	{"build.gradle.kts", regexp.MustCompile(`spring-boot-starter-amqp|amqp-client`), ServiceRabbitMQ},
+	{"build.gradle.kts", regexp.MustCompile(`spring-kafka|kafka-clients`), ServiceKafka},
+	{"build.gradle.kts", regexp.MustCompile(`spring-boot-starter-data-elasticsearch|elasticsearch-java`), ServiceElasticsearch},
+	// Go modules
+	{"go.mod", regexp.MustCompile(`github\.com/(redis|go-redis)/`), ServiceRedis},
+	{"go.mod", regexp.MustCompile(`github\.com/bradfitz/gomemcache`), ServiceMemcached},
+	{"go.mod", regexp.MustCompile(`github\.com/(rabbitmq/amqp091-go|streadway/amqp)`), ServiceRabbitMQ},
+	{"go.mod", regexp.MustCompile(`github\.com/(segmentio/kafka-go|IBM/sarama|Shopify/sarama|confluentinc/confluent-kafka-go)`), ServiceKafka},
+	{"go.mod", regexp.MustCompile(`github\.com/elastic/go-elasticsearch`), ServiceElasticsearch},
+	// Bundler
+	{"Gemfile", regexp.MustCompile(`^\s*gem\s+["'](redis|sidekiq|resque)["']`), ServiceRedis},
+	{"Gemfile", regexp.MustCompile(`^\s*gem\s+["']dalli["']`), ServiceMemcached},
+	{"Gemfile", regexp.MustCompile(`^\s*gem\s+["'](bunny|sneakers)["']`), ServiceRabbitMQ},
+	{"Gemfile", regexp.MustCompile(`^\s*gem\s+["'](ruby-kafka|rdkafka|karafka)["']`), ServiceKafka},
+	{"Gemfile", regexp.MustCompile(`^\s*gem\s+["'](elasticsearch|searchkick)["']`), ServiceElasticsearch},
+	// Composer
+	{"composer.json", regexp.MustCompile(`"predis/predis"`), ServiceRedis},
+	{"composer.json", regexp.MustCompile(`"(php-amqplib/php-amqplib|vladimir-yuldashev/laravel-queue-rabbitmq)"`), ServiceRabbitMQ},
+	{"composer.json", regexp.MustCompile(`"elasticsearch/elasticsearch"`), ServiceElasticsearch},
+	// Cargo
+	{"Cargo.toml", regexp.MustCompile(`^\s*(redis|deadpool-redis)\s*=`), ServiceRedis},
+	{"Cargo.toml", regexp.MustCompile(`^\s*lapin\s*=`), ServiceRabbitMQ},
+	{"Cargo.toml", regexp.MustCompile(`^\s*rdkafka\s*=`), ServiceKafka},
+	{"Cargo.toml", regexp.MustCompile(`^\s*elasticsearch\s*=`), ServiceElasticsearch},
+	// Mix
+	{"mix.exs", regexp.MustCompile(`\{:redix,`), ServiceRedis},
+	{"mix.exs", regexp.MustCompile(`\{:(amqp|broadway_rabbitmq),`), ServiceRabbitMQ},
+	{"mix.exs", regexp.MustCompile(`\{:(brod|kafka_ex|broadway_kafka),`), ServiceKafka},
+	// NuGet package references
+	{"*.csproj", regexp.MustCompile(`Include="(StackExchange\.Redis|Microsoft\.Extensions\.Caching\.StackExchangeRedis)"`), ServiceRedis},
+	{"*.csproj", regexp.MustCompile(`Include="RabbitMQ\.Client"`), ServiceRabbitMQ},
+	{"*.csproj", regexp.MustCompile(`Include="Confluent\.Kafka"`), ServiceKafka},
+	{"*.csproj", regexp.MustCompile(`Include="(Elastic\.Clients\.Elasticsearch|NEST)"`), ServiceElasticsearch},
+}
+
+// laravelServiceSettings are the .env.example settings that make Laravel use Redis.
+var laravelServiceSettings = regexp.MustCompile(`^(CACHE_DRIVER|CACHE_STORE|QUEUE_CONNECTION|SESSION_DRIVER)=redis\b`)
+
+// setBackingServices records the caches, queues and search engines inferred from dirPath's dependencies.
+func setBackingServices(profile *ProjectProfile, dirPath string) {
+	profile.BackingServices = inferBackingServices(dirPath)
+}
+
+// inferBackingServices returns each backing service the project's manifests
+// point to, once, in the order the evidence was found.
+func inferBackingServices(dirPath string) []BackingService {
+	var services []BackingService
+	add := func(service string, e Evidence) {
+		for _, existing := range services {
+			if existing.Type == service {
+				return
+			}
+		}
+		services = append(services, BackingService{Type: service, Kind: serviceKinds[service], Evidence: e})
+	}
+
+	if line, text := findLine(filepath.Join(dirPath, ".env.example"), laravelServiceSettings.MatchString); line > 0 {
+		add(ServiceRedis, Evidence{File: ".env.example", Line: line, Detail: text})
+	}
+	matchDependencies(dirPath, pythonServiceClients, nodeServiceClients, serviceRules, add)
+	return services
+}
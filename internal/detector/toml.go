@@ -0,0 +1,141 @@
+package detector
+
+import (
+	"os"
+	"strings"
+)
+
+// sectionValue reads `key = value` from the [section] of a simple TOML or
+// INI file (pyproject.toml, Pipfile, setup.cfg, Cargo.toml...). Quotes around
+// the value are removed. An empty section means the top of the file, before
+// any header. It returns the value and its 1-based line, or "" and 0 if the
+// key is absent. Only single-line values are supported.
+func sectionValue(path, section, key string) (string, int) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", 0
+	}
+
+	current := ""
+	for i, raw := range strings.Split(string(content), "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			current = strings.TrimSpace(strings.Trim(line, "[]"))
+			continue
+		}
+		if current != section {
+			continue
+		}
+
+		name, value, ok := strings.Cut(line, "=")
+		if !ok || strings.Trim(strings.TrimSpace(name), `"'`) != key {
+			continue
+		}
+		return unquote(value), i + 1
+	}
+	return "", 0
+}
+
+// unquote trims whitespace, a trailing comment and surrounding quotes from a value.
+func unquote(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) > 0 && (value[0] == '"' || value[0] == '\'') {
+		if end := strings.IndexByte(value[1:], value[0]); end >= 0 {
+			return value[1 : end+1]
+		}
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = value[:i]
+	}
+	return strings.TrimSpace(value)
+}
+
+// tomlItem is a value or key read from a TOML file, with its 1-based line.
+type tomlItem struct {
+	Value string
+	Line  int
+}
+
+// sectionArray reads a string array `key = [ "a", "b" ]` from [section],
+// which may span several lines.
+func sectionArray(path, section, key string) []tomlItem {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var items []tomlItem
+	current := ""
+	inArray := false
+	for i, raw := range strings.Split(string(content), "\n") {
+		line := strings.TrimSpace(raw)
+		if !inArray {
+			if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") && !strings.Contains(line, "=") {
+				current = strings.TrimSpace(strings.Trim(line, "[]"))
+				continue
+			}
+			name, value, ok := strings.Cut(line, "=")
+			if current != section || !ok || strings.TrimSpace(name) != key {
+				continue
+			}
+			line = strings.TrimSpace(value)
+			if !strings.HasPrefix(line, "[") {
+				return nil
+			}
+			line = line[1:]
+			inArray = true
+		}
+
+		for line != "" {
+			line = strings.TrimLeft(line, " \t,")
+			if line == "" || strings.HasPrefix(line, "#") {
+				break
+			}
+			if line[0] == ']' {
+				return items
+			}
+			if line[0] != '"' && line[0] != '\'' {
+				break
+			}
+			end := strings.IndexByte(line[1:], line[0])
+			if end < 0 {
+				break
+			}
+			items = append(items, tomlItem{Value: line[1 : end+1], Line: i + 1})
+			line = line[end+2:]
+		}
+	}
+	return items
+}
+
+// sectionKeys lists the keys defined directly in [section], e.g. the
+// packages of a Pipfile or the dependencies of a Poetry project.
+func sectionKeys(path, section string) []tomlItem {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var keys []tomlItem
+	current := ""
+	for i, raw := range strings.Split(string(content), "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") && !strings.Contains(line, "=") {
+			current = strings.TrimSpace(strings.Trim(line, "[]"))
+			continue
+		}
+		if current != section {
+			continue
+		}
+		if name, _, ok := strings.Cut(line, "="); ok {
+			keys = append(keys, tomlItem{Value: strings.Trim(strings.TrimSpace(name), `"'`), Line: i + 1})
+		}
+	}
+	return keys
+}
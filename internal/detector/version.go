@@ -0,0 +1,52 @@
+package detector
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver"
+)
+
+// resolveVersionConstraint returns the major.minor of the first candidate
+// version that satisfies constraint. Candidates are checked in order, so
+// listing them oldest first picks the lowest supported version.
+func resolveVersionConstraint(constraintStr string, candidates []string) (string, error) {
+	// Use Masterminds/semver to parse the version constraint
+	constraint, err := semver.NewConstraint(constraintStr)
+	if err != nil {
+		return "", fmt.Errorf("invalid version constraint %q: %w", constraintStr, err)
+	}
+
+	for _, v := range candidates {
+		version, err := semver.NewVersion(v)
+		if err != nil {
+			continue // Skip invalid versions in our list
+		}
+		if constraint.Check(version) {
+			// Return major.minor version
+			return fmt.Sprintf("%d.%d", version.Major(), version.Minor()), nil
+		}
+	}
+
+	return "", fmt.Errorf("no compatible version found for constraint %s", constraintStr)
+}
+
+// majorMinor shortens a version such as "1.22.3" to "1.22".
+func majorMinor(version string) string {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return version
+	}
+	return parts[0] + "." + parts[1]
+}
+
+// compareVersions compares two versions such as "8.0" and "10.0", returning
+// -1, 0 or 1. Versions that cannot be parsed compare as strings.
+func compareVersions(a, b string) int {
+	va, errA := semver.NewVersion(a)
+	vb, errB := semver.NewVersion(b)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+	return va.Compare(vb)
+}
@@ -0,0 +1,154 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown around each change,
+// matching the default of `diff -u` and `git diff`.
+const contextLines = 3
+
+// OpKind describes how a line moves from the old text to the new one.
+type OpKind int
+
+const (
+	Equal OpKind = iota
+	Delete
+	Insert
+)
+
+// Op is one step of an edit script. A and B are the line indexes in the old
+// and new text; only the side(s) the op touches are meaningful.
+type Op struct {
+	Kind OpKind
+	A    int
+	B    int
+	Line string
+}
+
+// Lines splits text into lines, keeping each line's trailing newline so that
+// a missing newline at end of file shows up as a change.
+func Lines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// Compute returns a minimal edit script turning a into b, based on the
+// longest common subsequence of lines.
+func Compute(a, b []string) []Op {
+	// lcs[i][j] is the LCS length of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	ops := make([]Op, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, Op{Kind: Equal, A: i, B: j, Line: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, Op{Kind: Delete, A: i, B: j, Line: a[i]})
+			i++
+		default:
+			ops = append(ops, Op{Kind: Insert, A: i, B: j, Line: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, Op{Kind: Delete, A: i, B: j, Line: a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, Op{Kind: Insert, A: i, B: j, Line: b[j]})
+	}
+	return ops
+}
+
+// Unified renders a unified diff between two texts, labelled with the given
+// file names. It returns an empty string when the texts are identical.
+func Unified(fromName, toName, from, to string) string {
+	ops := Compute(Lines(from), Lines(to))
+
+	var changes []int
+	for i, op := range ops {
+		if op.Kind != Equal {
+			changes = append(changes, i)
+		}
+	}
+	if len(changes) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+
+	for c := 0; c < len(changes); {
+		start := max(changes[c]-contextLines, 0)
+		last := changes[c]
+		// Merge changes whose surrounding context would overlap into one hunk.
+		for c+1 < len(changes) && changes[c+1]-last <= 2*contextLines {
+			c++
+			last = changes[c]
+		}
+		c++
+		end := min(last+contextLines+1, len(ops))
+		writeHunk(&sb, ops[start:end])
+	}
+	return sb.String()
+}
+
+func writeHunk(sb *strings.Builder, ops []Op) {
+	aStart, bStart := ops[0].A, ops[0].B
+	aCount, bCount := 0, 0
+	for _, op := range ops {
+		if op.Kind != Insert {
+			aCount++
+		}
+		if op.Kind != Delete {
+			bCount++
+		}
+	}
+	// Line numbers are 1-based, except that an empty range names the line before it.
+	if aCount > 0 {
+		aStart++
+	}
+	if bCount > 0 {
+		bStart++
+	}
+	fmt.Fprintf(sb, "@@ -%d,%d +%d,%d @@\n", aStart, aCount, bStart, bCount)
+
+	for _, op := range ops {
+		prefix := " "
+		switch op.Kind {
+		case Delete:
+			prefix = "-"
+		case Insert:
+			prefix = "+"
+		}
+		sb.WriteString(prefix + op.Line)
+		if !strings.HasSuffix(op.Line, "\n") {
+			sb.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package diff
+
+import "testing"
+
+func TestUnified(t *testing.T) {
+	testCases := []struct {
+		name     string
+		from     string
+		to       string
+		expected string
+	}{
+		{
+			name:     "Identical texts",
+			from:     "a\nb\n",
+			to:       "a\nb\n",
+			expected: "",
+		},
+		{
+			name: "New file",
+			from: "",
+			to:   "a\nb\n",
+			expected: `--- old
++++ new
+@@ -0,0 +1,2 @@
++a
++b
+`,
+		},
+		{
+			name: "Separate hunks and missing trailing newline",
+			from: "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n",
+			to:   "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk",
+			expected: `--- old
++++ new
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -8,3 +8,4 @@
+ h
+ i
+ j
++k
+\ No newline at end of file
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Unified("old", "new", tc.from, tc.to)
+			if got != tc.expected {
+				t.Errorf("Unexpected diff.\n got:\n%s\nwant:\n%s", got, tc.expected)
+			}
+		})
+	}
+}
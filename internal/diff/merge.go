@@ -0,0 +1,129 @@
+package diff
+
+import "strings"
+
+// hunk replaces base lines [start, end) with lines.
+type hunk struct {
+	start, end int
+	lines      []string
+}
+
+// hunks groups an edit script from base into contiguous replacements.
+func hunks(ops []Op) []hunk {
+	var result []hunk
+	var current *hunk
+	for _, op := range ops {
+		if op.Kind == Equal {
+			current = nil
+			continue
+		}
+		if current == nil {
+			result = append(result, hunk{start: op.A, end: op.A})
+			current = &result[len(result)-1]
+		}
+		if op.Kind == Delete {
+			current.end = op.A + 1
+		} else {
+			current.lines = append(current.lines, op.Line)
+		}
+	}
+	return result
+}
+
+// apply renders base[start:end] with the given hunks, which must lie inside that range.
+func apply(base []string, start, end int, changes []hunk) []string {
+	var out []string
+	pos := start
+	for _, h := range changes {
+		out = append(out, base[pos:h.start]...)
+		out = append(out, h.lines...)
+		pos = h.end
+	}
+	return append(out, base[pos:end]...)
+}
+
+// Merge performs a three-way merge of ours and theirs, which both descend
+// from base. Changes made on only one side are taken as-is; overlapping
+// changes that differ are wrapped in git-style conflict markers labelled
+// oursName and theirsName. It returns the merged text and the number of conflicts.
+func Merge(base, ours, theirs, oursName, theirsName string) (string, int) {
+	baseLines := Lines(base)
+	oursHunks := hunks(Compute(baseLines, Lines(ours)))
+	theirsHunks := hunks(Compute(baseLines, Lines(theirs)))
+
+	var out []string
+	conflicts := 0
+	pos := 0
+	i, j := 0, 0
+	for i < len(oursHunks) || j < len(theirsHunks) {
+		// Start a group at whichever side changes base first.
+		var start, end int
+		if j >= len(theirsHunks) || (i < len(oursHunks) && oursHunks[i].start <= theirsHunks[j].start) {
+			start, end = oursHunks[i].start, oursHunks[i].end
+		} else {
+			start, end = theirsHunks[j].start, theirsHunks[j].end
+		}
+
+		// Pull in every hunk from either side that overlaps the group.
+		var groupOurs, groupTheirs []hunk
+		for {
+			if i < len(oursHunks) && (oursHunks[i].start < end || oursHunks[i].start == start) {
+				groupOurs = append(groupOurs, oursHunks[i])
+				end = max(end, oursHunks[i].end)
+				i++
+				continue
+			}
+			if j < len(theirsHunks) && (theirsHunks[j].start < end || theirsHunks[j].start == start) {
+				groupTheirs = append(groupTheirs, theirsHunks[j])
+				end = max(end, theirsHunks[j].end)
+				j++
+				continue
+			}
+			break
+		}
+
+		out = append(out, baseLines[pos:start]...)
+		oursSide := apply(baseLines, start, end, groupOurs)
+		theirsSide := apply(baseLines, start, end, groupTheirs)
+		switch {
+		case len(groupTheirs) == 0:
+			out = append(out, oursSide...)
+		case len(groupOurs) == 0:
+			out = append(out, theirsSide...)
+		case strings.Join(oursSide, "") == strings.Join(theirsSide, ""):
+			out = append(out, oursSide...)
+		default:
+			conflicts++
+			out = append(out, "<<<<<<< "+oursName+"\n")
+			out = append(out, terminated(oursSide)...)
+			out = append(out, "=======\n")
+			out = append(out, terminated(theirsSide)...)
+			out = append(out, ">>>>>>> "+theirsName+"\n")
+		}
+		pos = end
+	}
+	out = append(out, baseLines[pos:]...)
+	return strings.Join(out, ""), conflicts
+}
+
+// terminated makes sure the last line ends with a newline so a following
+// conflict marker starts on its own line.
+func terminated(lines []string) []string {
+	if len(lines) == 0 || strings.HasSuffix(lines[len(lines)-1], "\n") {
+		return lines
+	}
+	out := append([]string(nil), lines...)
+	out[len(out)-1] += "\n"
+	return out
+}
+
+// ConflictMarkers counts the conflicts Merge marked in text that are still unresolved.
+func ConflictMarkers(text string) int {
+	count := 0
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(line, "<<<<<<< ") {
+			count++
+		}
+	}
+	return count
+}
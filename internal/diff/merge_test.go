@@ -0,0 +1,64 @@
+package diff
+
+import "testing"
+
+func TestMerge(t *testing.T) {
+	base := "FROM python:3.10\nWORKDIR /app\nCOPY . .\nEXPOSE 8000\nCMD [\"uvicorn\"]\n"
+
+	testCases := []struct {
+		name              string
+		ours              string
+		theirs            string
+		expected          string
+		expectedConflicts int
+	}{
+		{
+			name:     "Only the template changed",
+			ours:     base,
+			theirs:   "FROM python:3.12\nWORKDIR /app\nCOPY . .\nEXPOSE 8000\nCMD [\"uvicorn\"]\n",
+			expected: "FROM python:3.12\nWORKDIR /app\nCOPY . .\nEXPOSE 8000\nCMD [\"uvicorn\"]\n",
+		},
+		{
+			name:     "Both sides changed different lines",
+			ours:     "FROM python:3.10\nWORKDIR /app\nCOPY . .\nEXPOSE 9000\nCMD [\"uvicorn\"]\n",
+			theirs:   "FROM python:3.12\nWORKDIR /app\nCOPY . .\nEXPOSE 8000\nCMD [\"uvicorn\"]\n",
+			expected: "FROM python:3.12\nWORKDIR /app\nCOPY . .\nEXPOSE 9000\nCMD [\"uvicorn\"]\n",
+		},
+		{
+			name:     "Both sides made the same change",
+			ours:     "FROM python:3.12\nWORKDIR /app\nCOPY . .\nEXPOSE 8000\nCMD [\"uvicorn\"]\n",
+			theirs:   "FROM python:3.12\nWORKDIR /app\nCOPY . .\nEXPOSE 8000\nCMD [\"uvicorn\"]\n",
+			expected: "FROM python:3.12\nWORKDIR /app\nCOPY . .\nEXPOSE 8000\nCMD [\"uvicorn\"]\n",
+		},
+		{
+			name:   "Both sides changed the same line",
+			ours:   "FROM python:3.11\nWORKDIR /app\nCOPY . .\nEXPOSE 8000\nCMD [\"uvicorn\"]\n",
+			theirs: "FROM python:3.12\nWORKDIR /app\nCOPY . .\nEXPOSE 8000\nCMD [\"uvicorn\"]\n",
+			expected: "<<<<<<< ours\nFROM python:3.11\n=======\nFROM python:3.12\n>>>>>>> theirs\n" +
+				"WORKDIR /app\nCOPY . .\nEXPOSE 8000\nCMD [\"uvicorn\"]\n",
+			expectedConflicts: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			merged, conflicts := Merge(base, tc.ours, tc.theirs, "ours", "theirs")
+			if merged != tc.expected {
+				t.Errorf("Unexpected merge result.\n got:\n%s\nwant:\n%s", merged, tc.expected)
+			}
+			if conflicts != tc.expectedConflicts {
+				t.Errorf("Expected %d conflicts, but got %d", tc.expectedConflicts, conflicts)
+			}
+		})
+	}
+}
+
+func TestConflictMarkers(t *testing.T) {
+	merged, conflicts := Merge("a\nb\n", "a\nours\n", "a\ntheirs\n", "local", "template")
+	if got := ConflictMarkers(merged); got != conflicts {
+		t.Errorf("Expected %d conflict(s), but got %d", conflicts, got)
+	}
+	if got := ConflictMarkers("a\nours\n"); got != 0 {
+		t.Errorf("Expected no conflicts in a resolved file, but got %d", got)
+	}
+}
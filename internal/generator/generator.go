@@ -1,32 +1,156 @@
 package generator
+
 import (
-    "os"
-    "text/template"
-    "github.com/Suprath/orchestrator-cli/internal/templates"
+	"bytes"
+	"os"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/Suprath/orchestrator-cli/internal/templates"
 )
 
 // Data struct holds the user's answers
 type TemplateData struct {
-    AppName string
-    LanguageVersion string
-    DatabaseType string
-    DeploymentEnvironment string
+	AppName               string `yaml:"app_name"`
+	LanguageVersion       string `yaml:"language_version"`
+	DatabaseType          string `yaml:"database_type"`
+	DeploymentEnvironment string `yaml:"deployment_environment"`
+	Framework             string `yaml:"framework,omitempty"`
+	Entrypoint            string `yaml:"entrypoint,omitempty"`
+	BuildTool             string `yaml:"build_tool,omitempty"`
+	BuildToolWrapper      bool   `yaml:"build_tool_wrapper,omitempty"`
+	PackageManager        string `yaml:"package_manager,omitempty"`
+	PackageManagerVersion string `yaml:"package_manager_version,omitempty"`
+	NoLockfile            bool   `yaml:"no_lockfile,omitempty"`
+	AssemblyName          string `yaml:"assembly_name,omitempty"`
+	RuntimeVersion        string `yaml:"runtime_version,omitempty"`
+	MigrationCommand      string `yaml:"migration_command,omitempty"`
+	// Port is the port the app listens on inside its container.
+	Port int `yaml:"port,omitempty"`
+	// HealthPath is the HTTP path probes check; when empty they only check that Port accepts connections.
+	HealthPath string `yaml:"health_path,omitempty"`
+	// Settings are the environment variables the app reads its configuration from.
+	Settings []Setting `yaml:"settings,omitempty"`
+	// BackingServices are the caches, queues and search engines run next to the app.
+	BackingServices []BackingService `yaml:"backing_services,omitempty"`
+	// IngressHost is the host name an Ingress routes to the app; no Ingress is generated when it is empty.
+	IngressHost string `yaml:"ingress_host,omitempty"`
+	// IngressTLS serves the Ingress over HTTPS with a certificate issued by cert-manager.
+	IngressTLS bool `yaml:"ingress_tls,omitempty"`
+	// Services lists the services of a monorepo; it is empty for a single-service project.
+	Services []Service `yaml:"services,omitempty"`
+}
+
+// Service is one service of a monorepo: where it lives and the data its own files are rendered with.
+type Service struct {
+	Name      string       `yaml:"name"` // e.g. "api", used for compose services and manifest directories
+	Path      string       `yaml:"path"` // slash-separated, relative to the repository root
+	Archetype string       `yaml:"archetype"`
+	Data      TemplateData `yaml:"data"`
+}
+
+// For returns the data file is rendered with: its service's data, or d itself
+// for files that belong to the whole repository.
+func (d TemplateData) For(file FileSpec) TemplateData {
+	for _, service := range d.Services {
+		if service.Name == file.Service {
+			return service.Data
+		}
+	}
+	return d
+}
+
+// FileSpec pairs a template in templates.TemplateFS with the file it renders to.
+type FileSpec struct {
+	TemplatePath string `yaml:"template"`
+	OutputPath   string `yaml:"output"`
+	// Service names the monorepo service whose data renders this file, if any.
+	Service string `yaml:"service,omitempty"`
+}
+
+// FilesFor returns the files generated for an archetype, in generation order.
+// Common templates are shared by every archetype; the rest live in the archetype's directory.
+func FilesFor(archetype string) []FileSpec {
+	files := []FileSpec{
+		{TemplatePath: "common/docker-compose.yml.tmpl", OutputPath: "docker-compose.yml"},
+		{TemplatePath: "common/terraform/eks_fargate.tf.tmpl", OutputPath: "terraform/main.tf"},
+	}
+	files = append(files, kubernetesFiles()...)
+	files = append(files,
+		FileSpec{TemplatePath: path.Join(archetype, "Dockerfile.tmpl"), OutputPath: "Dockerfile"},
+		FileSpec{TemplatePath: path.Join(archetype, "pipeline.yml.tmpl"), OutputPath: ".github/workflows/pipeline.yml"},
+	)
+	return append(files, extraFiles(archetype)...)
 }
 
+// extraFiles returns the files only some archetypes need, on top of the standard set.
+func extraFiles(archetype string) []FileSpec {
+	switch archetype {
+	case "nodejs_spa":
+		// The Dockerfile copies this into the nginx image to serve the build.
+		return []FileSpec{{TemplatePath: path.Join(archetype, "nginx.conf.tmpl"), OutputPath: "nginx.conf"}}
+	case "ruby_rails":
+		return []FileSpec{{TemplatePath: path.Join(archetype, "migrate-job.yml.tmpl"), OutputPath: "kubernetes/migrate-job.yml"}}
+	}
+	return nil
+}
+
+// MonorepoFilesFor returns the files generated for a repository of several
+// services. The compose file, Terraform and the pipeline are shared; each
+// service gets its Dockerfile (and any extras, such as its .env.example) in
+// its own directory and its Kubernetes manifests under kubernetes/<name>/.
+func MonorepoFilesFor(services []Service) []FileSpec {
+	files := []FileSpec{
+		{TemplatePath: "monorepo/docker-compose.yml.tmpl", OutputPath: "docker-compose.yml"},
+		{TemplatePath: "common/terraform/eks_fargate.tf.tmpl", OutputPath: "terraform/main.tf"},
+		{TemplatePath: "monorepo/pipeline.yml.tmpl", OutputPath: ".github/workflows/pipeline.yml"},
+	}
+	for _, service := range services {
+		serviceFiles := append(kubernetesFiles(), FileSpec{TemplatePath: path.Join(service.Archetype, "Dockerfile.tmpl"), OutputPath: "Dockerfile"})
+		serviceFiles = append(serviceFiles, extraFiles(service.Archetype)...)
+		serviceFiles = append(serviceFiles, FilesForSettings(service.Data.Settings)...)
+		serviceFiles = append(serviceFiles, FilesForIngress(service.Data.IngressHost)...)
+		for _, file := range serviceFiles {
+			if rest, ok := strings.CutPrefix(file.OutputPath, "kubernetes/"); ok {
+				file.OutputPath = path.Join("kubernetes", service.Name, rest)
+			} else {
+				file.OutputPath = path.Join(service.Path, file.OutputPath)
+			}
+			file.Service = service.Name
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// funcs are the helpers available to every template, in addition to the text/template builtins.
+var funcs = template.FuncMap{
+	"hasPrefix": strings.HasPrefix,
+	"hasSuffix": strings.HasSuffix,
+	"add":       func(a, b int) int { return a + b },
+}
+
+// Render executes a template against data and returns the output without touching disk.
+func Render(templatePath string, data TemplateData) ([]byte, error) {
+	// Read the template from the active (possibly overridden) template layers
+	tmpl, err := template.New(path.Base(templatePath)).Funcs(funcs).ParseFS(templates.Source, templatePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GenerateFile renders a template and writes the result to outputPath, replacing any existing file.
 func GenerateFile(templatePath string, outputPath string, data TemplateData) error {
-    // Read the template from the embedded filesystem
-    tmpl, err := template.ParseFS(templates.TemplateFS, templatePath)
-    if err != nil {
-        return err
-    }
-
-    // Create the output file
-    outputFile, err := os.Create(outputPath)
-    if err != nil {
-        return err
-    }
-    defer outputFile.Close()
-
-    // Execute the template, writing the result to the file
-    return tmpl.Execute(outputFile, data)
-}
\ No newline at end of file
+	content, err := Render(templatePath, data)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(outputPath, content, 0644)
+}
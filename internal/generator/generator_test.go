@@ -0,0 +1,112 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// representativeData fills in every optional part of TemplateData so each
+// conditional block of the templates gets rendered.
+func representativeData(archetype string) TemplateData {
+	return TemplateData{
+		AppName:               "demo",
+		DatabaseType:          "postgresql",
+		DeploymentEnvironment: "cloud",
+		MigrationCommand:      "bin/rails db:migrate",
+		Port:                  8080,
+		HealthPath:            "/health",
+		Settings: []Setting{
+			{Name: "LOG_LEVEL", Value: "info", Description: "How much to log"},
+			{Name: "API_TOKEN", Secret: true},
+		},
+		BackingServices: []BackingService{{Type: "redis"}, {Type: "rabbitmq"}},
+		IngressHost:     archetype + ".example.com",
+		IngressTLS:      true,
+	}
+}
+
+func TestRender_AllFiles(t *testing.T) {
+	// Each case overrides the fields its archetype's templates read.
+	testCases := []struct {
+		archetype       string
+		languageVersion string
+		packageManager  string
+		entrypoint      string
+		prepare         func(data *TemplateData)
+	}{
+		{archetype: "python_fastapi", languageVersion: "3.11", packageManager: "poetry", entrypoint: "app.main:app"},
+		{archetype: "python_django", languageVersion: "3.12", packageManager: "pip", entrypoint: "demo.wsgi"},
+		{archetype: "python_flask", languageVersion: "3.12", packageManager: "uv", entrypoint: "app:app"},
+		{archetype: "nodejs_server", languageVersion: "20", packageManager: "pnpm", prepare: func(d *TemplateData) { d.PackageManagerVersion = "9" }},
+		{archetype: "nodejs_spa", languageVersion: "20", packageManager: "yarn"},
+		{archetype: "nodejs_ssr", languageVersion: "20", packageManager: "npm"},
+		{archetype: "nodejs_nextjs", languageVersion: "20", packageManager: "bun"},
+		{archetype: "java_spring_boot", languageVersion: "21", prepare: func(d *TemplateData) { d.BuildTool, d.BuildToolWrapper = "gradle", true }},
+		{archetype: "go_service", languageVersion: "1.22", entrypoint: "./cmd/server"},
+		{archetype: "rust_service", languageVersion: "1.78", entrypoint: "server"},
+		{archetype: "dotnet_aspnet", languageVersion: "8.0", prepare: func(d *TemplateData) { d.AssemblyName = "Demo.Api" }},
+		{archetype: "ruby_rails", languageVersion: "3.3", prepare: func(d *TemplateData) { d.DatabaseType = "mysql" }},
+		{archetype: "elixir_phoenix", languageVersion: "1.16", prepare: func(d *TemplateData) { d.RuntimeVersion = "26" }},
+		{archetype: "php_laravel", languageVersion: "8.3", prepare: func(d *TemplateData) { d.Port, d.IngressHost = 9000, "" }},
+	}
+
+	var services []Service
+	for _, tc := range testCases {
+		data := representativeData(tc.archetype)
+		data.LanguageVersion, data.PackageManager, data.Entrypoint = tc.languageVersion, tc.packageManager, tc.entrypoint
+		if tc.prepare != nil {
+			tc.prepare(&data)
+		}
+		services = append(services, Service{Name: tc.archetype, Path: "services/" + tc.archetype, Archetype: tc.archetype, Data: data})
+
+		t.Run(tc.archetype, func(t *testing.T) {
+			files := FilesFor(tc.archetype)
+			files = append(files, FilesForBackingServices(data.BackingServices)...)
+			files = append(files, FilesForSettings(data.Settings)...)
+			files = append(files, FilesForIngress(data.IngressHost)...)
+			for _, file := range files {
+				renderAndParse(t, file, data)
+			}
+		})
+	}
+
+	t.Run("monorepo", func(t *testing.T) {
+		data := TemplateData{AppName: "demo", DatabaseType: "postgresql", DeploymentEnvironment: "cloud", Services: services}
+		for _, file := range MonorepoFilesFor(services) {
+			renderAndParse(t, file, data.For(file))
+		}
+	})
+}
+
+// renderAndParse renders file and, for YAML output, checks that every document parses.
+func renderAndParse(t *testing.T, file FileSpec, data TemplateData) {
+	t.Helper()
+	content, err := Render(file.TemplatePath, data)
+	if err != nil {
+		t.Errorf("Expected %s to render, but got: %v", file.TemplatePath, err)
+		return
+	}
+	if len(bytes.TrimSpace(content)) == 0 {
+		t.Errorf("Expected %s to produce output, but it was empty", file.TemplatePath)
+	}
+	if !strings.HasSuffix(file.OutputPath, ".yml") && !strings.HasSuffix(file.OutputPath, ".yaml") {
+		return
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var doc interface{}
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Errorf("Expected %s to be valid YAML, but got: %v\n%s", file.OutputPath, err, content)
+			return
+		}
+	}
+}
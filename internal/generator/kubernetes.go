@@ -0,0 +1,49 @@
+package generator
+
+import "fmt"
+
+// Labels are the labels every Kubernetes object of the app carries. "app" is
+// also the Deployment's selector, which cannot change once applied, so it
+// keeps the name it has always had.
+func (d TemplateData) Labels() map[string]string {
+	return map[string]string{
+		"app":                          d.AppName,
+		"app.kubernetes.io/name":       d.AppName,
+		"app.kubernetes.io/managed-by": "orchestrator",
+	}
+}
+
+// DatabaseURL is the app's connection string when the database is reachable
+// at host, with the development credentials docker-compose.yml also uses.
+func (d TemplateData) DatabaseURL(host string) string {
+	switch d.DatabaseType {
+	case "mysql":
+		return fmt.Sprintf("mysql://root:password@%s:3306/%s", host, d.AppName)
+	case "postgresql":
+		return fmt.Sprintf("postgresql://myuser:mypassword@%s:5432/%s", host, d.AppName)
+	case "mongodb":
+		return fmt.Sprintf("mongodb://%s:27017/%s", host, d.AppName)
+	}
+	return "your_custom_database_url"
+}
+
+// kubernetesFiles are the manifests every service gets: the Deployment, the
+// Service in front of it, its ServiceAccount and the ConfigMap and Secret it
+// reads its environment from.
+func kubernetesFiles() []FileSpec {
+	return []FileSpec{
+		{TemplatePath: "common/kubernetes/deployment.yml.tmpl", OutputPath: "kubernetes/deployment.yml"},
+		{TemplatePath: "common/kubernetes/service.yml.tmpl", OutputPath: "kubernetes/service.yml"},
+		{TemplatePath: "common/kubernetes/serviceaccount.yml.tmpl", OutputPath: "kubernetes/serviceaccount.yml"},
+		{TemplatePath: "common/kubernetes/configmap.yml.tmpl", OutputPath: "kubernetes/configmap.yml"},
+		{TemplatePath: "common/kubernetes/secret.yml.tmpl", OutputPath: "kubernetes/secret.yml"},
+	}
+}
+
+// FilesForIngress returns the Ingress manifest when the app is exposed on a host, and none otherwise.
+func FilesForIngress(host string) []FileSpec {
+	if host == "" {
+		return nil
+	}
+	return []FileSpec{{TemplatePath: "common/kubernetes/ingress.yml.tmpl", OutputPath: "kubernetes/ingress.yml"}}
+}
@@ -0,0 +1,123 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// BackingService is a cache, queue or search engine the app runs alongside.
+// Only the type is stored; images, ports and connection URLs come from the
+// catalog below, so upgrading the CLI picks up new defaults.
+type BackingService struct {
+	Type string `yaml:"type"` // e.g. "redis"
+}
+
+// EnvVar is a name/value pair for a container's environment.
+type EnvVar struct {
+	Name  string
+	Value string
+}
+
+// backingServiceSpec describes how to run one type of backing service.
+type backingServiceSpec struct {
+	kind     string // cache, queue or search
+	image    string
+	port     int
+	envVar   string // the variable the app reads the connection from
+	url      string // connection URL, with %s standing for the host
+	dataPath string // where the server keeps its data; empty if it keeps none
+	// env configures the server container, with %s standing for its own host.
+	env []EnvVar
+}
+
+var backingServiceCatalog = map[string]backingServiceSpec{
+	"redis": {
+		kind: "cache", image: "redis:7-alpine", port: 6379, envVar: "REDIS_URL", url: "redis://%s:6379/0", dataPath: "/data",
+	},
+	"memcached": {
+		kind: "cache", image: "memcached:1.6-alpine", port: 11211, envVar: "MEMCACHED_SERVERS", url: "%s:11211",
+	},
+	"rabbitmq": {
+		kind: "queue", image: "rabbitmq:3-management-alpine", port: 5672, envVar: "RABBITMQ_URL", url: "amqp://guest:guest@%s:5672/", dataPath: "/var/lib/rabbitmq",
+	},
+	"kafka": {
+		kind: "queue", image: "apache/kafka:3.7.0", port: 9092, envVar: "KAFKA_BROKERS", url: "%s:9092", dataPath: "/var/lib/kafka/data",
+		// A single combined broker and controller in KRaft mode
+		env: []EnvVar{
+			{"KAFKA_NODE_ID", "1"},
+			{"KAFKA_PROCESS_ROLES", "broker,controller"},
+			{"KAFKA_LISTENERS", "PLAINTEXT://:9092,CONTROLLER://:9093"},
+			{"KAFKA_ADVERTISED_LISTENERS", "PLAINTEXT://%s:9092"},
+			{"KAFKA_CONTROLLER_LISTENER_NAMES", "CONTROLLER"},
+			{"KAFKA_LISTENER_SECURITY_PROTOCOL_MAP", "CONTROLLER:PLAINTEXT,PLAINTEXT:PLAINTEXT"},
+			{"KAFKA_CONTROLLER_QUORUM_VOTERS", "1@localhost:9093"},
+			{"KAFKA_OFFSETS_TOPIC_REPLICATION_FACTOR", "1"},
+			{"KAFKA_LOG_DIRS", "/var/lib/kafka/data"},
+		},
+	},
+	"elasticsearch": {
+		kind: "search", image: "docker.elastic.co/elasticsearch/elasticsearch:8.13.4", port: 9200, envVar: "ELASTICSEARCH_URL", url: "http://%s:9200", dataPath: "/usr/share/elasticsearch/data",
+		env: []EnvVar{
+			{"discovery.type", "single-node"},
+			{"xpack.security.enabled", "false"},
+			{"ES_JAVA_OPTS", "-Xms512m -Xmx512m"},
+		},
+	},
+}
+
+// FilesForBackingServices returns the extra files needed to run services
+// next to the app: none when there are no services.
+func FilesForBackingServices(services []BackingService) []FileSpec {
+	if len(services) == 0 {
+		return nil
+	}
+	return []FileSpec{{TemplatePath: "common/kubernetes/backing-services.yml.tmpl", OutputPath: "kubernetes/backing-services.yml"}}
+}
+
+// BackingServiceTypes lists the backing service types the templates can render, sorted.
+func BackingServiceTypes() []string {
+	types := make([]string, 0, len(backingServiceCatalog))
+	for t := range backingServiceCatalog {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Kind is what the service is for: cache, queue or search.
+func (s BackingService) Kind() string { return backingServiceCatalog[s.Type].kind }
+
+// Image is the container image the service runs.
+func (s BackingService) Image() string { return backingServiceCatalog[s.Type].image }
+
+// Port is the port clients connect to.
+func (s BackingService) Port() int { return backingServiceCatalog[s.Type].port }
+
+// EnvVar is the environment variable the app reads the service's address from.
+func (s BackingService) EnvVar() string { return backingServiceCatalog[s.Type].envVar }
+
+// URL is the address the app connects to when the service is reachable at host.
+func (s BackingService) URL(host string) string {
+	return fmt.Sprintf(backingServiceCatalog[s.Type].url, host)
+}
+
+// Secret reports whether the service's URL carries credentials, so it belongs in a Secret.
+func (s BackingService) Secret() bool {
+	return strings.Contains(backingServiceCatalog[s.Type].url, "@")
+}
+
+// DataPath is the directory the service stores data in, or "" when it keeps none.
+func (s BackingService) DataPath() string { return backingServiceCatalog[s.Type].dataPath }
+
+// ContainerEnv is the service container's own configuration when it is reachable at host.
+func (s BackingService) ContainerEnv(host string) []EnvVar {
+	var env []EnvVar
+	for _, v := range backingServiceCatalog[s.Type].env {
+		if strings.Contains(v.Value, "%s") {
+			v.Value = fmt.Sprintf(v.Value, host)
+		}
+		env = append(env, v)
+	}
+	return env
+}
@@ -0,0 +1,57 @@
+package generator
+
+import "strings"
+
+// Setting is an environment variable the app reads its configuration from.
+// Settings go into the .env file used by docker-compose and into a ConfigMap,
+// or a Secret when they hold credentials, for Kubernetes.
+type Setting struct {
+	Name        string `yaml:"name"`
+	Value       string `yaml:"value,omitempty"` // the example or default value found in the project
+	Secret      bool   `yaml:"secret,omitempty"`
+	Description string `yaml:"description,omitempty"`
+	Source      string `yaml:"source,omitempty"` // where the variable was found, e.g. "config/app.php:16"
+}
+
+// DotenvValue is the value written to a .env file, quoted when it would not survive unquoted.
+func (s Setting) DotenvValue() string {
+	if strings.ContainsAny(s.Value, " \t#\"'$") {
+		return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`).Replace(s.Value) + `"`
+	}
+	return s.Value
+}
+
+// ConfigSettings are the settings that are not secret, in detection order.
+func (d TemplateData) ConfigSettings() []Setting {
+	var settings []Setting
+	for _, s := range d.Settings {
+		if !s.Secret {
+			settings = append(settings, s)
+		}
+	}
+	return settings
+}
+
+// SecretSettings are the settings that hold credentials, in detection order.
+func (d TemplateData) SecretSettings() []Setting {
+	var settings []Setting
+	for _, s := range d.Settings {
+		if s.Secret {
+			settings = append(settings, s)
+		}
+	}
+	return settings
+}
+
+// EnvExampleTemplate renders the documented .env.example.
+const EnvExampleTemplate = "common/env.example.tmpl"
+
+// FilesForSettings returns the documented .env.example listing the app's
+// settings, or none when there are no settings. The ConfigMap and Secret are
+// part of every service's manifests.
+func FilesForSettings(settings []Setting) []FileSpec {
+	if len(settings) == 0 {
+		return nil
+	}
+	return []FileSpec{{TemplatePath: EnvExampleTemplate, OutputPath: ".env.example"}}
+}
@@ -0,0 +1,37 @@
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// BaseDir keeps a copy of the last output the CLI rendered for each file,
+// relative to the project root. `upgrade` uses it as the common ancestor
+// when merging new template output into a file the user has edited.
+const BaseDir = ".orchestrator/base"
+
+// StoreBase saves content as the generated base of outputPath.
+func StoreBase(outputPath string, content []byte) error {
+	basePath := filepath.Join(BaseDir, outputPath)
+	if err := os.MkdirAll(filepath.Dir(basePath), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create base directory: %w", err)
+	}
+	if err := os.WriteFile(basePath, content, 0644); err != nil {
+		return fmt.Errorf("failed to store base for %s: %w", outputPath, err)
+	}
+	return nil
+}
+
+// ReadBase returns the stored base of outputPath. The boolean is false when
+// no base was stored, e.g. for files generated by an older CLI.
+func ReadBase(outputPath string) ([]byte, bool, error) {
+	content, err := os.ReadFile(filepath.Join(BaseDir, outputPath))
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read base for %s: %w", outputPath, err)
+	}
+	return content, true, nil
+}
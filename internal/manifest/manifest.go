@@ -0,0 +1,175 @@
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/Suprath/orchestrator-cli/internal/generator"
+	"github.com/Suprath/orchestrator-cli/internal/templates"
+)
+
+// Path is where the manifest lives, relative to the project root.
+const Path = ".orchestrator/manifest.json"
+
+// manifestVersion is the schema version written to new manifests.
+const manifestVersion = 1
+
+// State describes how a generated file compares to what the CLI last wrote.
+type State string
+
+const (
+	// StatePristine means the file is exactly as generated and the template is unchanged.
+	StatePristine State = "pristine"
+	// StateStale means the file is untouched, but regenerating would now produce different output.
+	StateStale State = "stale"
+	// StateModified means the file has been edited since it was generated.
+	StateModified State = "modified"
+	// StateMissing means the file was generated but no longer exists.
+	StateMissing State = "missing"
+	// StateUntracked means the file has no manifest entry, e.g. it was never written.
+	StateUntracked State = "untracked"
+)
+
+// Entry records how a single output file was produced.
+type Entry struct {
+	Template     string `json:"template"`
+	TemplateHash string `json:"template_hash"`
+	DataHash     string `json:"data_hash"`
+	OutputHash   string `json:"output_hash"`
+}
+
+// Manifest maps each generated output path to the entry that produced it.
+type Manifest struct {
+	Version int              `json:"version"`
+	Files   map[string]Entry `json:"files"`
+}
+
+// Status is the result of comparing one generated file with its manifest entry.
+type Status struct {
+	Path  string
+	State State
+	// TemplateChanged is set when the template or the answers changed since generation,
+	// whatever the state of the file on disk.
+	TemplateChanged bool
+}
+
+// Load reads the manifest at path. A missing manifest is not an error; an empty one is returned.
+func Load(path string) (*Manifest, error) {
+	m := &Manifest{Version: manifestVersion, Files: map[string]Entry{}}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+	}
+	if m.Files == nil {
+		m.Files = map[string]Entry{}
+	}
+	return m, nil
+}
+
+// Save writes the manifest to path, creating its directory if needed.
+func (m *Manifest) Save(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create manifest directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+// Record stores the hashes for a file that was just written with output.
+func (m *Manifest) Record(file generator.FileSpec, data generator.TemplateData, output []byte) error {
+	templateHash, err := TemplateHash(file.TemplatePath)
+	if err != nil {
+		return err
+	}
+	dataHash, err := DataHash(data)
+	if err != nil {
+		return err
+	}
+
+	m.Files[file.OutputPath] = Entry{
+		Template:     file.TemplatePath,
+		TemplateHash: templateHash,
+		DataHash:     dataHash,
+		OutputHash:   Hash(output),
+	}
+	return nil
+}
+
+// Check compares a generated file on disk, and the template it comes from,
+// against its manifest entry.
+func (m *Manifest) Check(file generator.FileSpec, data generator.TemplateData) (Status, error) {
+	status := Status{Path: file.OutputPath}
+
+	entry, ok := m.Files[file.OutputPath]
+	if !ok {
+		status.State = StateUntracked
+		return status, nil
+	}
+
+	templateHash, err := TemplateHash(file.TemplatePath)
+	if err != nil {
+		return status, err
+	}
+	dataHash, err := DataHash(data)
+	if err != nil {
+		return status, err
+	}
+	status.TemplateChanged = file.TemplatePath != entry.Template || templateHash != entry.TemplateHash || dataHash != entry.DataHash
+
+	content, err := os.ReadFile(file.OutputPath)
+	switch {
+	case os.IsNotExist(err):
+		status.State = StateMissing
+	case err != nil:
+		return status, fmt.Errorf("failed to read %s: %w", file.OutputPath, err)
+	case Hash(content) != entry.OutputHash:
+		status.State = StateModified
+	case status.TemplateChanged:
+		status.State = StateStale
+	default:
+		status.State = StatePristine
+	}
+	return status, nil
+}
+
+// Hash returns the hex-encoded SHA-256 checksum of b, prefixed with the algorithm.
+func Hash(b []byte) string {
+	sum := sha256.Sum256(b)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// TemplateHash hashes the raw content of a template as resolved through templates.Source.
+func TemplateHash(templatePath string) (string, error) {
+	content, err := fs.ReadFile(templates.Source, templatePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read template %s: %w", templatePath, err)
+	}
+	return Hash(content), nil
+}
+
+// DataHash hashes the answers a file was rendered with.
+func DataHash(data generator.TemplateData) (string, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode template data: %w", err)
+	}
+	return Hash(encoded), nil
+}
@@ -0,0 +1,67 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Suprath/orchestrator-cli/internal/generator"
+)
+
+func TestManifest_Check(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "orchestrator-test-manifest-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	data := generator.TemplateData{AppName: "demo", LanguageVersion: "3.9", DatabaseType: "postgresql", DeploymentEnvironment: "cloud"}
+	file := generator.FileSpec{TemplatePath: "python_fastapi/Dockerfile.tmpl", OutputPath: filepath.Join(tempDir, "Dockerfile")}
+
+	rendered, err := generator.Render(file.TemplatePath, data)
+	if err != nil {
+		t.Fatalf("Failed to render template: %v", err)
+	}
+	os.WriteFile(file.OutputPath, rendered, 0644)
+
+	m, err := Load(filepath.Join(tempDir, Path))
+	if err != nil {
+		t.Fatalf("Did not expect an error, but got: %v", err)
+	}
+	if err := m.Record(file, data, rendered); err != nil {
+		t.Fatalf("Did not expect an error, but got: %v", err)
+	}
+
+	changedData := data
+	changedData.AppName = "renamed"
+
+	steps := []struct {
+		name          string
+		prepare       func()
+		data          generator.TemplateData
+		expectedState State
+	}{
+		{name: "Freshly generated", prepare: func() {}, data: data, expectedState: StatePristine},
+		{name: "Answers changed", prepare: func() {}, data: changedData, expectedState: StateStale},
+		{name: "Edited by the user", prepare: func() { os.WriteFile(file.OutputPath, []byte("FROM scratch\n"), 0644) }, data: data, expectedState: StateModified},
+		{name: "Deleted", prepare: func() { os.Remove(file.OutputPath) }, data: data, expectedState: StateMissing},
+	}
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			step.prepare()
+			status, err := m.Check(file, step.data)
+			if err != nil {
+				t.Fatalf("Did not expect an error, but got: %v", err)
+			}
+			if status.State != step.expectedState {
+				t.Errorf("Expected state %s, but got %s", step.expectedState, status.State)
+			}
+		})
+	}
+
+	untracked := generator.FileSpec{TemplatePath: file.TemplatePath, OutputPath: "elsewhere"}
+	if status, _ := m.Check(untracked, data); status.State != StateUntracked {
+		t.Errorf("Expected state %s, but got %s", StateUntracked, status.State)
+	}
+}
@@ -0,0 +1,174 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Source is the filesystem templates are rendered from. It defaults to the
+// embedded templates and is replaced with a LayeredFS once the CLI knows the
+// project directory and any --template-dir.
+var Source fs.FS = TemplateFS
+
+// Layer is one place templates can be loaded from.
+type Layer struct {
+	Name     string // e.g. "project", "user", "template-dir", "embedded"
+	Location string // where the layer lives on disk, empty for embedded templates
+	FS       fs.FS
+}
+
+// LayeredFS resolves each template from the first layer that contains it,
+// so a single file or a whole archetype directory can be overridden on its own.
+type LayeredFS struct {
+	Layers []Layer
+}
+
+// Resolved describes which layer a template name resolves to.
+type Resolved struct {
+	Name  string
+	Layer Layer
+}
+
+// NewLayeredFS builds the lookup chain for a project, in priority order:
+// the project's .orchestrator/templates, the user's config directory, the
+// optional templateDir (a directory or a .tar/.tar.gz/.tgz pack), and finally
+// the embedded defaults. Layers that do not exist are left out.
+func NewLayeredFS(projectDir, templateDir string) (*LayeredFS, error) {
+	var layers []Layer
+
+	projectTemplates := filepath.Join(projectDir, ".orchestrator", "templates")
+	if isDir(projectTemplates) {
+		layers = append(layers, Layer{Name: "project", Location: projectTemplates, FS: os.DirFS(projectTemplates)})
+	}
+
+	if userTemplates := userTemplateDir(); userTemplates != "" && isDir(userTemplates) {
+		layers = append(layers, Layer{Name: "user", Location: userTemplates, FS: os.DirFS(userTemplates)})
+	}
+
+	if templateDir != "" {
+		layer, err := templateDirLayer(templateDir)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, layer)
+	}
+
+	layers = append(layers, Layer{Name: "embedded", FS: TemplateFS})
+	return &LayeredFS{Layers: layers}, nil
+}
+
+// Open implements fs.FS by opening name from the first layer that has it.
+func (l *LayeredFS) Open(name string) (fs.File, error) {
+	layer, err := l.Resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	return layer.FS.Open(name)
+}
+
+// Resolve returns the layer name would be loaded from.
+func (l *LayeredFS) Resolve(name string) (Layer, error) {
+	if !fs.ValidPath(name) {
+		return Layer{}, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	for _, layer := range l.Layers {
+		info, err := fs.Stat(layer.FS, name)
+		if err == nil && !info.IsDir() {
+			return layer, nil
+		}
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return Layer{}, fmt.Errorf("failed to read template %s from %s layer: %w", name, layer.Name, err)
+		}
+	}
+	return Layer{}, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// List returns every template (*.tmpl) visible through the layers, sorted by
+// name, together with the layer it resolves from.
+func (l *LayeredFS) List() ([]Resolved, error) {
+	seen := map[string]bool{}
+	var names []string
+	for _, layer := range l.Layers {
+		files, err := templateFiles(layer.FS)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s templates: %w", layer.Name, err)
+		}
+		for _, name := range files {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	resolved := make([]Resolved, 0, len(names))
+	for _, name := range names {
+		layer, err := l.Resolve(name)
+		if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, Resolved{Name: name, Layer: layer})
+	}
+	return resolved, nil
+}
+
+// templateFiles lists the *.tmpl files in fsys.
+func templateFiles(fsys fs.FS) ([]string, error) {
+	if pack, ok := fsys.(packFS); ok {
+		return pack.files(), nil
+	}
+
+	var files []string
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(path, ".tmpl") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// userTemplateDir is $XDG_CONFIG_HOME/orchestrator/templates, falling back to
+// the platform's user config directory when XDG_CONFIG_HOME is not set.
+func userTemplateDir() string {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return ""
+		}
+		configHome = dir
+	}
+	return filepath.Join(configHome, "orchestrator", "templates")
+}
+
+// templateDirLayer loads the --template-dir layer from a directory or a tarball pack.
+func templateDirLayer(path string) (Layer, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return Layer{}, fmt.Errorf("template directory %s: %w", path, err)
+	}
+	if info.IsDir() {
+		return Layer{Name: "template-dir", Location: path, FS: os.DirFS(path)}, nil
+	}
+
+	pack, err := loadPack(path)
+	if err != nil {
+		return Layer{}, err
+	}
+	return Layer{Name: "template-dir", Location: path, FS: pack}, nil
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,79 @@
+package templates
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLayeredFS_ResolveOrder(t *testing.T) {
+	projectDir, err := os.MkdirTemp("", "orchestrator-test-templates-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(projectDir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(projectDir, "xdg"))
+
+	// Project override for a single file.
+	overrideDir := filepath.Join(projectDir, ".orchestrator", "templates", "python_fastapi")
+	os.MkdirAll(overrideDir, 0755)
+	os.WriteFile(filepath.Join(overrideDir, "Dockerfile.tmpl"), []byte("FROM project\n"), 0644)
+
+	// User override for the same file (shadowed by the project) and another one.
+	userDir := filepath.Join(projectDir, "xdg", "orchestrator", "templates", "python_fastapi")
+	os.MkdirAll(userDir, 0755)
+	os.WriteFile(filepath.Join(userDir, "Dockerfile.tmpl"), []byte("FROM user\n"), 0644)
+	os.WriteFile(filepath.Join(userDir, "pipeline.yml.tmpl"), []byte("name: user\n"), 0644)
+
+	// Template pack overriding a common template.
+	packPath := filepath.Join(projectDir, "pack.tar.gz")
+	writePack(t, packPath, map[string]string{"common/docker-compose.yml.tmpl": "services: {}\n"})
+
+	layered, err := NewLayeredFS(projectDir, packPath)
+	if err != nil {
+		t.Fatalf("Did not expect an error, but got: %v", err)
+	}
+
+	expected := map[string]string{
+		"python_fastapi/Dockerfile.tmpl":        "project",
+		"python_fastapi/pipeline.yml.tmpl":      "user",
+		"common/docker-compose.yml.tmpl":        "template-dir",
+		"common/kubernetes/deployment.yml.tmpl": "embedded",
+	}
+	for name, layerName := range expected {
+		layer, err := layered.Resolve(name)
+		if err != nil {
+			t.Errorf("Did not expect an error resolving %s, but got: %v", name, err)
+			continue
+		}
+		if layer.Name != layerName {
+			t.Errorf("Expected %s to resolve from %s, but got %s", name, layerName, layer.Name)
+		}
+	}
+
+	content, err := fs.ReadFile(layered, "common/docker-compose.yml.tmpl")
+	if err != nil || string(content) != "services: {}\n" {
+		t.Errorf("Expected pack content, but got %q (err: %v)", content, err)
+	}
+}
+
+func writePack(t *testing.T, packPath string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(packPath)
+	if err != nil {
+		t.Fatalf("Failed to create pack: %v", err)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		tw.Write([]byte(content))
+	}
+	tw.Close()
+	gz.Close()
+}
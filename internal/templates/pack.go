@@ -0,0 +1,105 @@
+package templates
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// packFS is a template pack loaded from a tarball into memory. Its layout
+// mirrors the embedded templates, e.g. "python_fastapi/Dockerfile.tmpl".
+type packFS map[string][]byte
+
+// loadPack reads a .tar, .tar.gz or .tgz template pack.
+func loadPack(packPath string) (packFS, error) {
+	f, err := os.Open(packPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open template pack: %w", err)
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	switch {
+	case strings.HasSuffix(packPath, ".tar.gz"), strings.HasSuffix(packPath, ".tgz"):
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress template pack %s: %w", packPath, err)
+		}
+		defer gz.Close()
+		r = gz
+	case strings.HasSuffix(packPath, ".tar"):
+	default:
+		return nil, fmt.Errorf("unsupported template pack %s (expected a directory, .tar, .tar.gz or .tgz)", packPath)
+	}
+
+	pack := packFS{}
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template pack %s: %w", packPath, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := path.Clean(strings.TrimPrefix(header.Name, "./"))
+		if !fs.ValidPath(name) {
+			return nil, fmt.Errorf("template pack %s contains an invalid path %q", packPath, header.Name)
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from template pack: %w", name, err)
+		}
+		pack[name] = content
+	}
+	return pack, nil
+}
+
+// Open implements fs.FS. Only regular files can be opened; a directory
+// reports fs.ErrNotExist, which is all template lookup needs.
+func (p packFS) Open(name string) (fs.File, error) {
+	content, ok := p[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return &packFile{name: name, Reader: bytes.NewReader(content)}, nil
+}
+
+// files lists the templates in the pack.
+func (p packFS) files() []string {
+	var files []string
+	for name := range p {
+		if strings.HasSuffix(name, ".tmpl") {
+			files = append(files, name)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+type packFile struct {
+	name string
+	*bytes.Reader
+}
+
+func (f *packFile) Stat() (fs.FileInfo, error) { return f, nil }
+func (f *packFile) Close() error               { return nil }
+
+// packFile is its own fs.FileInfo.
+func (f *packFile) Name() string       { return path.Base(f.name) }
+func (f *packFile) Mode() fs.FileMode  { return 0444 }
+func (f *packFile) ModTime() time.Time { return time.Time{} }
+func (f *packFile) IsDir() bool        { return false }
+func (f *packFile) Sys() any           { return nil }
@@ -1,5 +1,5 @@
 package templates
 import "embed"
 
-//go:embed all:common all:java_spring_boot all:python_fastapi all:php_laravel all:nodejs_nextjs
+//go:embed all:common all:java_spring_boot all:python_fastapi all:php_laravel all:nodejs_nextjs all:go_service all:nodejs_server all:nodejs_ssr all:nodejs_spa all:python_django all:python_flask all:ruby_rails all:dotnet_aspnet all:rust_service all:elixir_phoenix all:monorepo
 var TemplateFS embed.FS
\ No newline at end of file